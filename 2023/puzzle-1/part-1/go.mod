@@ -0,0 +1,7 @@
+module github.com/maaxleq/advent-of-code-2023/2023/puzzle-1/part-1
+
+go 1.21.1
+
+require github.com/maaxleq/advent-of-code-2023/lib v0.0.0
+
+replace github.com/maaxleq/advent-of-code-2023/lib => ../../../lib
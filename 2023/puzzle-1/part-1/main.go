@@ -1,31 +1,16 @@
 package main
 
 import (
-	"bufio"
-	"fmt"
 	"log"
-	"os"
 	"strconv"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+	"github.com/maaxleq/advent-of-code-2023/lib/trace"
 )
 
 const inputFile = "input.txt"
 
-// readFileLines reads a file and returns its contents as an array of strings.
-func readFileLines(filePath string) ([]string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	return lines, scanner.Err()
-}
-
 func findFirstLastDigits(line string) [2]int {
 	runes := []rune(line)
 
@@ -33,6 +18,7 @@ func findFirstLastDigits(line string) [2]int {
 	for i := 0; i < len(runes); i++ {
 		if digit, err := strconv.Atoi(line[i : i+1]); err == nil {
 			firstDigit = digit
+			trace.Emit("token", "which", "first", "token", line[i:i+1], "pos", i, "value", digit)
 			break
 		}
 	}
@@ -41,6 +27,7 @@ func findFirstLastDigits(line string) [2]int {
 	for i := len(runes) - 1; i >= 0; i-- {
 		if digit, err := strconv.Atoi(line[i : i+1]); err == nil {
 			lastDigit = digit
+			trace.Emit("token", "which", "last", "token", line[i:i+1], "pos", i, "value", digit)
 			break
 		}
 	}
@@ -53,16 +40,17 @@ func computeCalibrationValue(digits [2]int) int {
 }
 
 func main() {
-	lines, errRead := readFileLines(inputFile)
+	lines, errRead := input.ReadLines(inputFile)
 	if errRead != nil {
 		log.Fatal(errRead)
 	}
 
 	sum := 0
-	for _, line := range lines {
+	for i, line := range lines {
 		calibrationValue := computeCalibrationValue(findFirstLastDigits(line))
+		trace.Emit("calibration", "line", i+1, "value", calibrationValue)
 		sum += calibrationValue
 	}
 
-	fmt.Println(sum)
+	solution.Print(sum)
 }
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// benchmarkPoolSize is the number of distinct generated lines, cycled through until the requested size is scanned.
+const benchmarkPoolSize = 1 << 16
+
+// naiveFirstLastDigits finds the first and last digits of a line by trying every word of the lexicon at every position.
+// It is the original approach of the solver, copied as is with the lexicon in place of the digit map,
+// kept as a baseline for the benchmark.
+func naiveFirstLastDigits(line string, lex lexicon) ([2]int, error) {
+	var matches []string
+
+	for i := 0; i < len(line); i++ {
+		// Check for each digit word
+		for word := range lex {
+			if strings.HasPrefix(line[i:], word) {
+				matches = append(matches, word)
+				break
+			}
+		}
+
+		// Check if the character is a digit
+		if len(line) > i && line[i] >= '0' && line[i] <= '9' {
+			matches = append(matches, line[i:i+1])
+		}
+	}
+
+	if len(matches) < 1 {
+		return [2]int{}, fmt.Errorf("not enough digits found")
+	}
+
+	firstDigit, errFirst := lex.getDigit(matches[0])
+	if errFirst != nil {
+		return [2]int{}, errFirst
+	}
+
+	lastDigit, errLast := lex.getDigit(matches[len(matches)-1])
+	if errLast != nil {
+		return [2]int{}, errLast
+	}
+
+	return [2]int{firstDigit, lastDigit}, nil
+}
+
+// parseSize parses a size in bytes, with an optional K, M or G binary suffix.
+func parseSize(s string) (int64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+
+	digits, multiplier := s, int64(1)
+	switch strings.ToUpper(s[len(s)-1:]) {
+	case "K":
+		multiplier = 1 << 10
+	case "M":
+		multiplier = 1 << 20
+	case "G":
+		multiplier = 1 << 30
+	}
+	if multiplier != 1 {
+		digits = s[:len(s)-1]
+	}
+
+	n, errParse := strconv.ParseInt(digits, 10, 64)
+	if errParse != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+
+	return n * multiplier, nil
+}
+
+// generateLines returns random lines looking like the puzzle input: letters mixing words of the lexicon,
+// often overlapping like "twone", with a few digits. Every line holds at least one digit.
+func generateLines(lex lexicon, count int) []string {
+	rng := rand.New(rand.NewSource(1))
+
+	words := []string{}
+	for word := range lex {
+		words = append(words, word)
+	}
+	// Map iteration order is random, sort the words for the lines to be the same on every run.
+	sort.Strings(words)
+
+	lines := make([]string, count)
+	for i := range lines {
+		var sb strings.Builder
+		for sb.Len() < 20+rng.Intn(40) {
+			switch r := rng.Intn(10); {
+			case r < 3:
+				word := words[rng.Intn(len(words))]
+				// Drop the first letter from time to time, to make words overlap with the previous ones.
+				if rng.Intn(3) == 0 {
+					sb.WriteString(word[1:])
+				} else {
+					sb.WriteString(word)
+				}
+			case r < 4:
+				sb.WriteByte(byte('0' + rng.Intn(10)))
+			default:
+				sb.WriteByte(byte('a' + rng.Intn(26)))
+			}
+		}
+		sb.WriteByte(byte('0' + rng.Intn(10)))
+
+		lines[i] = sb.String()
+	}
+
+	return lines
+}
+
+// runBenchmark times the naive approach and the scanner on generated lines totalling size bytes,
+// and writes how fast each one went. Both must agree on the sum of the calibration values,
+// it returns an error otherwise.
+func runBenchmark(w io.Writer, lex lexicon, sc *scanner, size int64, combine combineRule) error {
+	lines := generateLines(lex, benchmarkPoolSize)
+
+	approaches := []struct {
+		name string
+		find func(line string) ([2]int, error)
+	}{
+		{"naive", func(line string) ([2]int, error) {
+			return naiveFirstLastDigits(line, lex)
+		}},
+		{"aho-corasick", func(line string) ([2]int, error) {
+			return findFirstLastDigits(line, sc)
+		}},
+	}
+
+	fmt.Fprintf(w, "scanning %.1f MiB of generated lines\n", float64(size)/(1<<20))
+	sums := make([]int, len(approaches))
+	for i, approach := range approaches {
+		start := time.Now()
+
+		sum, scanned, count := 0, int64(0), 0
+		for scanned < size {
+			line := lines[count%len(lines)]
+			digits, errDigits := approach.find(line)
+			if errDigits != nil {
+				return fmt.Errorf("%s: %w", approach.name, errDigits)
+			}
+
+			sum += computeCalibrationValue(digits, combine)
+			scanned += int64(len(line)) + 1 // Count the newline which would end the line in a file.
+			count++
+		}
+
+		elapsed := time.Since(start)
+		fmt.Fprintf(w, "%-12s %10s %10.1f MiB/s  %d lines, sum %d\n",
+			approach.name, elapsed.Round(time.Millisecond), float64(scanned)/(1<<20)/elapsed.Seconds(), count, sum)
+		sums[i] = sum
+	}
+
+	for i := 1; i < len(approaches); i++ {
+		if sums[i] != sums[0] {
+			return fmt.Errorf("%s sum %d differs from %s sum %d", approaches[i].name, sums[i], approaches[0].name, sums[0])
+		}
+	}
+
+	return nil
+}
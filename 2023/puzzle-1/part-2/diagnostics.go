@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// diagnosis describes what the scanner found in a line, and what may be wrong with it.
+type diagnosis struct {
+	lineNumber int
+	line       string
+	tokens     []match // Every digit and word found in the line.
+	digits     [2]int  // Values of the first and last tokens, when the line is valid.
+	value      int     // Calibration value, when the line is valid.
+	invalid    bool    // Whether no calibration value can be computed from the line.
+	err        error   // Why the line is invalid.
+	problems   []string
+}
+
+// diagnose scans a line for problems. A line is invalid if it holds no digit, and suspicious if its calibration
+// value uses a single token twice, if its first and last tokens overlap, or if it holds digits outside of any token,
+// like digits of other scripts when the tokenizer only knows ASCII ones.
+func diagnose(lineNumber int, line string, t tokenizer, combine combineRule) diagnosis {
+	d := diagnosis{
+		lineNumber: lineNumber,
+		line:       line,
+		tokens:     t.all(line),
+	}
+
+	covered := make([]bool, len(line))
+	for _, token := range d.tokens {
+		for i := token.pos; i < token.pos+len(token.word); i++ {
+			covered[i] = true
+		}
+	}
+	unrecognized := []rune{}
+	for i, r := range line {
+		if unicode.IsDigit(r) && !covered[i] {
+			unrecognized = append(unrecognized, r)
+		}
+	}
+	if len(unrecognized) > 0 {
+		d.problems = append(d.problems, fmt.Sprintf("unrecognized digits %q", string(unrecognized)))
+	}
+
+	digits, errDigits := findFirstLastDigits(line, t)
+	if errDigits != nil {
+		d.invalid, d.err = true, errDigits
+		d.problems = append(d.problems, errDigits.Error())
+		return d
+	}
+	d.digits = digits
+	d.value = computeCalibrationValue(digits, combine)
+
+	first, last, _ := t.firstLast(line)
+	switch {
+	case first == last:
+		d.problems = append(d.problems, fmt.Sprintf("single token %q used as both first and last", first.word))
+	case first.pos+len(first.word) > last.pos:
+		d.problems = append(d.problems, fmt.Sprintf("first token %q overlaps last token %q", first.word, last.word))
+	}
+
+	return d
+}
+
+// write writes the diagnosis as a few indented lines.
+func (d diagnosis) write(w io.Writer) {
+	status := "suspicious"
+	if d.invalid {
+		status = "invalid"
+	}
+	fmt.Fprintf(w, "line %d: %s: %s\n", d.lineNumber, status, strings.Join(d.problems, ", "))
+	fmt.Fprintf(w, "    text: %q\n", d.line)
+
+	tokens := []string{}
+	for _, t := range d.tokens {
+		tokens = append(tokens, fmt.Sprintf("%s@%d", t.word, t.pos))
+	}
+	fmt.Fprintf(w, "    tokens: %s\n", strings.Join(tokens, " "))
+
+	if !d.invalid {
+		fmt.Fprintf(w, "    calibration value: %d\n", d.value)
+	}
+}
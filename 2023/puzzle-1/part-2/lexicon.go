@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// lexicon maps spelled out digits to their numeric equivalents.
+// Words may share prefixes or overlap, like "eight" and "three" in "threeight": both are found.
+type lexicon map[string]int
+
+// builtinLexicons holds the lexicons which can be selected by name, keyed by language code.
+var builtinLexicons = map[string]lexicon{
+	"en": {
+		"one":   1,
+		"two":   2,
+		"three": 3,
+		"four":  4,
+		"five":  5,
+		"six":   6,
+		"seven": 7,
+		"eight": 8,
+		"nine":  9,
+	},
+	"fr": {
+		"un":     1,
+		"une":    1,
+		"deux":   2,
+		"trois":  3,
+		"quatre": 4,
+		"cinq":   5,
+		"six":    6,
+		"sept":   7,
+		"huit":   8,
+		"neuf":   9,
+	},
+	"de": {
+		"ein":    1,
+		"eins":   1,
+		"zwei":   2,
+		"drei":   3,
+		"vier":   4,
+		"fünf":   5,
+		"fuenf":  5,
+		"sechs":  6,
+		"sieben": 7,
+		"acht":   8,
+		"neun":   9,
+	},
+	"es": {
+		"uno":    1,
+		"una":    1,
+		"dos":    2,
+		"tres":   3,
+		"cuatro": 4,
+		"cinco":  5,
+		"seis":   6,
+		"siete":  7,
+		"ocho":   8,
+		"nueve":  9,
+	},
+}
+
+// builtinNames returns the names of the built-in lexicons, sorted.
+func builtinNames() []string {
+	names := []string{}
+	for name := range builtinLexicons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// merge adds the words of other to the lexicon.
+// It returns an error if a word is already in the lexicon with a different value.
+func (l lexicon) merge(other lexicon) error {
+	for word, value := range other {
+		if existing, exists := l[word]; exists && existing != value {
+			return fmt.Errorf("word %q means both %d and %d", word, existing, value)
+		}
+		l[word] = value
+	}
+
+	return nil
+}
+
+// getDigit converts a spelled-out digit or a single digit, of any script, to its numerical equivalent.
+func (l lexicon) getDigit(s string) (int, error) {
+	if val, exists := l[s]; exists {
+		return val, nil
+	}
+
+	if r, size := utf8.DecodeRuneInString(s); size == len(s) {
+		if val, isDigit := digitValue(r); isDigit {
+			return val, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid digit: %s", s)
+}
+
+// loadLexicon builds a lexicon from a comma separated list of built-in lexicon names,
+// and from a file if path is not empty. Names and the file may be combined, as long as they agree on every word.
+func loadLexicon(names, path string) (lexicon, error) {
+	lex := make(lexicon)
+
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		builtin, exists := builtinLexicons[name]
+		if !exists {
+			return nil, fmt.Errorf("unknown lexicon %q, expected one of %s", name, strings.Join(builtinNames(), ", "))
+		}
+
+		if errMerge := lex.merge(builtin); errMerge != nil {
+			return nil, fmt.Errorf("cannot add lexicon %s: %w", name, errMerge)
+		}
+	}
+
+	if path != "" {
+		file, errFile := readLexiconFile(path)
+		if errFile != nil {
+			return nil, errFile
+		}
+
+		if errMerge := lex.merge(file); errMerge != nil {
+			return nil, fmt.Errorf("cannot add lexicon %s: %w", path, errMerge)
+		}
+	}
+
+	if len(lex) == 0 {
+		return nil, fmt.Errorf("empty lexicon")
+	}
+
+	return lex, nil
+}
+
+// readLexiconFile reads a lexicon from a file holding a "word digit" pair per line.
+// Blank lines and lines starting with # are ignored.
+func readLexiconFile(path string) (lexicon, error) {
+	file, errOpen := os.Open(path)
+	if errOpen != nil {
+		return nil, fmt.Errorf("cannot read lexicon: %w", errOpen)
+	}
+	defer file.Close()
+
+	lex := make(lexicon)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected a word and a digit, got %q", path, lineNumber, line)
+		}
+
+		value, errValue := strconv.Atoi(fields[1])
+		if errValue != nil || value < 0 || value > 9 {
+			return nil, fmt.Errorf("%s:%d: invalid digit %q", path, lineNumber, fields[1])
+		}
+
+		if errMerge := lex.merge(lexicon{fields[0]: value}); errMerge != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNumber, errMerge)
+		}
+	}
+
+	if errScan := scanner.Err(); errScan != nil {
+		return nil, fmt.Errorf("cannot read lexicon: %w", errScan)
+	}
+
+	return lex, nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+	"github.com/maaxleq/advent-of-code-2023/lib/trace"
+)
+
+const inputFile = "input.txt"
+
+// tokenizer finds the tokens a calibration value is computed from in a line: digits, or whole numbers.
+type tokenizer interface {
+	// all returns every token of the line.
+	all(line string) []match
+	// firstLast returns the first and last tokens of the line, and false if there is none.
+	firstLast(line string) (match, match, bool)
+}
+
+// findFirstLastDigits finds and returns the values of the first and last tokens of a string:
+// digits (numerical or spelled out with the lexicon of a scanner) or numbers, depending on the tokenizer.
+func findFirstLastDigits(line string, t tokenizer) ([2]int, error) {
+	first, last, found := t.firstLast(line)
+	if !found {
+		return [2]int{}, fmt.Errorf("not enough digits found")
+	}
+
+	if trace.Enabled() {
+		trace.Emit("token", "token", first.word, "pos", first.pos, "value", first.value)
+		trace.Emit("token", "token", last.word, "pos", last.pos, "value", last.value)
+	}
+
+	return [2]int{first.value, last.value}, nil
+}
+
+// combineRule computes a calibration value from the values of the first and last tokens of a line.
+type combineRule func(first, last int) int
+
+// combineRules holds the rules which can be selected by name.
+var combineRules = map[string]combineRule{
+	// concat writes the last value after the first one, which for digits is the puzzle's rule.
+	"concat": func(first, last int) int {
+		shift := 10
+		for shift <= last {
+			shift *= 10
+		}
+		return first*shift + last
+	},
+	"sum": func(first, last int) int {
+		return first + last
+	},
+	"product": func(first, last int) int {
+		return first * last
+	},
+	"max": func(first, last int) int {
+		return max(first, last)
+	},
+	"min": func(first, last int) int {
+		return min(first, last)
+	},
+}
+
+// combineRuleNames returns the names of the combine rules, sorted.
+func combineRuleNames() []string {
+	names := []string{}
+	for name := range combineRules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// computeCalibrationValue combines the values of the first and last tokens of a line into its calibration value.
+func computeCalibrationValue(values [2]int, combine combineRule) int {
+	return combine(values[0], values[1])
+}
+
+func main() {
+	lexiconNames := flag.String("lexicon", "en", "comma separated built-in lexicons of spelled out digits, among "+strings.Join(builtinNames(), ", "))
+	lexiconFile := flag.String("lexicon-file", "", "file of additional spelled out digits, one \"word digit\" pair per line")
+	benchmark := flag.String("benchmark", "", "instead of solving the puzzle, compare the scanners on generated lines of the given total size, like 512M or 1G")
+	diagnostics := flag.Bool("diagnostics", false, "report every invalid or suspicious line on standard error, with the tokens found in it")
+	skipInvalid := flag.Bool("skip-invalid", false, "leave lines without digits out of the sum instead of failing")
+	numbers := flag.Bool("numbers", false, "use the first and last numbers of lines, spelled out in English or written with digits of any script, instead of digits")
+	combine := flag.String("combine", "concat", "rule combining the first and last digits or numbers into a calibration value, among "+strings.Join(combineRuleNames(), ", "))
+	flag.Parse()
+
+	if *numbers {
+		// The number scanner only knows English words, which a lexicon cannot change.
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "lexicon" || f.Name == "lexicon-file" {
+				log.Fatalf("-numbers cannot be combined with -%s, numbers are only spelled out in English", f.Name)
+			}
+		})
+	}
+
+	rule, exists := combineRules[*combine]
+	if !exists {
+		log.Fatalf("unknown combine rule %q, expected one of %s", *combine, strings.Join(combineRuleNames(), ", "))
+	}
+
+	lex, errLexicon := loadLexicon(*lexiconNames, *lexiconFile)
+	if errLexicon != nil {
+		log.Fatal(errLexicon)
+	}
+	sc := newScanner(lex)
+
+	var t tokenizer = sc
+	if *numbers {
+		t = numberScanner{}
+	}
+
+	if *benchmark != "" {
+		size, errSize := parseSize(*benchmark)
+		if errSize != nil {
+			log.Fatal(errSize)
+		}
+
+		if errBenchmark := runBenchmark(os.Stdout, lex, sc, size, rule); errBenchmark != nil {
+			log.Fatal(errBenchmark)
+		}
+		return
+	}
+
+	lines, errRead := input.ReadLines(inputFile)
+	if errRead != nil {
+		log.Fatal(errRead)
+	}
+
+	sum, invalid, suspicious := 0, 0, 0
+	for i, line := range lines {
+		var digits [2]int
+		var errDigits error
+		if *diagnostics {
+			// The diagnosis already holds the digits, scanning the line again would trace its tokens twice.
+			d := diagnose(i+1, line, t, rule)
+			if len(d.problems) > 0 {
+				d.write(os.Stderr)
+				if !d.invalid {
+					suspicious++
+				}
+			}
+			digits, errDigits = d.digits, d.err
+		} else {
+			digits, errDigits = findFirstLastDigits(line, t)
+		}
+
+		if errDigits != nil {
+			// Diagnostics go on with the following lines, to report all of them.
+			if !*skipInvalid && !*diagnostics {
+				log.Fatalf("line %d: %v", i+1, errDigits)
+			}
+			invalid++
+			continue
+		}
+
+		calibrationValue := computeCalibrationValue(digits, rule)
+		trace.Emit("calibration", "line", i+1, "value", calibrationValue)
+		sum += calibrationValue
+	}
+
+	if *diagnostics {
+		fmt.Fprintf(os.Stderr, "%d lines, %d invalid, %d suspicious\n", len(lines), invalid, suspicious)
+	}
+	switch {
+	case invalid > 0 && !*skipInvalid:
+		log.Fatalf("%d invalid lines, use -skip-invalid to leave them out of the sum", invalid)
+	case invalid > 0 && !*diagnostics:
+		fmt.Fprintf(os.Stderr, "left %d invalid lines out of the sum\n", invalid)
+	}
+
+	solution.Print(sum)
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxNumberDigits is the maximum number of digits of a number, so that two of them concatenated fit in an int.
+// Longer runs of digits are split into several numbers.
+const maxNumberDigits = 9
+
+// numberWords maps the English words numbers are spelled out with to their values.
+var numberWords = map[string]int{
+	"zero":      0,
+	"one":       1,
+	"two":       2,
+	"three":     3,
+	"four":      4,
+	"five":      5,
+	"six":       6,
+	"seven":     7,
+	"eight":     8,
+	"nine":      9,
+	"ten":       10,
+	"eleven":    11,
+	"twelve":    12,
+	"thirteen":  13,
+	"fourteen":  14,
+	"fifteen":   15,
+	"sixteen":   16,
+	"seventeen": 17,
+	"eighteen":  18,
+	"nineteen":  19,
+	"twenty":    20,
+	"thirty":    30,
+	"forty":     40,
+	"fifty":     50,
+	"sixty":     60,
+	"seventy":   70,
+	"eighty":    80,
+	"ninety":    90,
+	"hundred":   100,
+	"thousand":  1_000,
+	"million":   1_000_000,
+}
+
+// digitValue returns the value of a decimal digit of any script, like '٣' or the full-width '３', and false if
+// the rune is not one. Unicode lays out decimal digits in runs of ten starting with zero.
+func digitValue(r rune) (int, bool) {
+	if !unicode.IsDigit(r) {
+		return 0, false
+	}
+
+	for _, rng := range unicode.Nd.R16 {
+		if lo, hi := rune(rng.Lo), rune(rng.Hi); r >= lo && r <= hi {
+			return int(r-lo) % 10, true
+		}
+	}
+	for _, rng := range unicode.Nd.R32 {
+		if lo, hi := rune(rng.Lo), rune(rng.Hi); r >= lo && r <= hi {
+			return int(r-lo) % 10, true
+		}
+	}
+
+	return 0, false
+}
+
+// wordAt returns the value and the end of the longest number word starting at pos, and false if there is none.
+func wordAt(line string, pos int) (int, int, bool) {
+	value, end := 0, -1
+	for word, v := range numberWords {
+		if pos+len(word) > end && strings.HasPrefix(line[pos:], word) {
+			value, end = v, pos+len(word)
+		}
+	}
+
+	return value, end, end >= 0
+}
+
+// separated returns the positions where the word following pos may start: right there, or after a space or a hyphen.
+func separated(line string, pos int) []int {
+	if pos < len(line) && (line[pos] == ' ' || line[pos] == '-') {
+		return []int{pos, pos + 1}
+	}
+
+	return []int{pos}
+}
+
+// continued returns the positions where the rest of a number may start after a scale word ending at pos,
+// which like in British English may be after "and".
+func continued(line string, pos int) []int {
+	starts := separated(line, pos)
+	for _, start := range separated(line, pos) {
+		if strings.HasPrefix(line[start:], "and") {
+			starts = append(starts, separated(line, start+len("and"))...)
+		}
+	}
+
+	return starts
+}
+
+// parseBelow100 parses a number from zero to ninety-nine spelled out at pos, like "seven", "twelve" or "forty-two".
+// It returns its value and its end, and false if there is no such number.
+func parseBelow100(line string, pos int) (int, int, bool) {
+	value, end, found := wordAt(line, pos)
+	if !found || value >= 100 {
+		return 0, 0, false
+	}
+
+	if value >= 20 && value%10 == 0 {
+		for _, next := range separated(line, end) {
+			if unit, unitEnd, found := wordAt(line, next); found && unit > 0 && unit < 10 {
+				return value + unit, unitEnd, true
+			}
+		}
+	}
+
+	return value, end, true
+}
+
+// parseBelow1000 parses a number below a thousand spelled out at pos, like "one hundred and five".
+// It returns its value and its end, and false if there is no such number.
+func parseBelow1000(line string, pos int) (int, int, bool) {
+	value, end, found := parseBelow100(line, pos)
+	if !found || value == 0 {
+		return value, end, found
+	}
+
+	for _, next := range separated(line, end) {
+		if scale, scaleEnd, found := wordAt(line, next); found && scale == 100 {
+			value, end = value*100, scaleEnd
+
+			for _, start := range continued(line, end) {
+				if rest, restEnd, found := parseBelow100(line, start); found && rest > 0 {
+					return value + rest, restEnd, true
+				}
+			}
+
+			return value, end, true
+		}
+	}
+
+	return value, end, true
+}
+
+// parseNumberWords parses a number spelled out at pos, groups below a thousand being followed by decreasing scales
+// like in "two million forty thousand and one". It returns its value and its end, and false if there is no number.
+func parseNumberWords(line string, pos int) (int, int, bool) {
+	group, end, found := parseBelow1000(line, pos)
+	if !found {
+		return 0, 0, false
+	}
+
+	total, lastScale := 0, numberWords["million"]+1
+	for scaled := true; scaled; {
+		scaled = false
+		for _, next := range separated(line, end) {
+			scale, scaleEnd, found := wordAt(line, next)
+			if !found || scale < 1_000 || scale >= lastScale || group == 0 {
+				continue
+			}
+
+			total += group * scale
+			group, end, lastScale, scaled = 0, scaleEnd, scale, true
+
+			for _, start := range continued(line, end) {
+				if rest, restEnd, found := parseBelow1000(line, start); found && rest > 0 {
+					group, end = rest, restEnd
+					break
+				}
+			}
+			break
+		}
+	}
+
+	return total + group, end, true
+}
+
+// parseDigits parses a run of decimal digits of any script at pos, of at most maxNumberDigits digits.
+// It returns its value and its end, and false if there is no digit at pos.
+func parseDigits(line string, pos int) (int, int, bool) {
+	value, end := 0, pos
+	for count := 0; end < len(line) && count < maxNumberDigits; count++ {
+		r, size := utf8.DecodeRuneInString(line[end:])
+		digit, isDigit := digitValue(r)
+		if !isDigit {
+			break
+		}
+
+		value = value*10 + digit
+		end += size
+	}
+
+	return value, end, end > pos
+}
+
+// numberScanner finds the numbers of lines: runs of decimal digits of any script, and English numbers spelled out
+// like "twelve", "twenty-one" or "one hundred".
+type numberScanner struct{}
+
+// all returns the numbers of the line, from left to right. Like digits, spelled out numbers may share
+// their last letter with the following one, as in "oneight".
+func (numberScanner) all(line string) []match {
+	matches := []match{}
+	for pos := 0; pos < len(line); {
+		if value, end, found := parseDigits(line, pos); found {
+			matches = append(matches, match{word: line[pos:end], pos: pos, value: value})
+			pos = end
+			continue
+		}
+
+		if value, end, found := parseNumberWords(line, pos); found {
+			matches = append(matches, match{word: line[pos:end], pos: pos, value: value})
+			pos = end - 1
+			continue
+		}
+
+		pos++
+	}
+
+	return matches
+}
+
+// firstLast returns the first and last numbers of the line, and false if there is none.
+func (ns numberScanner) firstLast(line string) (match, match, bool) {
+	matches := ns.all(line)
+	if len(matches) == 0 {
+		return match{}, match{}, false
+	}
+
+	return matches[0], matches[len(matches)-1], true
+}
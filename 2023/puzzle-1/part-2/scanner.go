@@ -0,0 +1,202 @@
+package main
+
+import "strconv"
+
+// automaton is an Aho-Corasick automaton matching a set of words in a single pass over a string.
+// Its transitions are a dense table over bytes, so that matching costs one lookup per byte.
+type automaton struct {
+	delta  []int32 // delta[state<<8|b] is the state reached from state by reading the byte b.
+	output []int32 // Index in words of the longest word ending at each state, -1 if none.
+	word   []int32 // Index in words of the word spelled by each state of the trie, -1 if none.
+	fail   []int32 // State of the longest proper suffix of the word spelled by each state of the trie.
+	words  []string
+}
+
+// newAutomaton builds an automaton matching the given words, which must not be empty.
+func newAutomaton(words []string) *automaton {
+	a := &automaton{
+		delta:  make([]int32, 256),
+		output: []int32{-1},
+		word:   []int32{-1},
+		words:  words,
+	}
+	for b := range a.delta {
+		a.delta[b] = -1
+	}
+
+	// Build the trie of the words, missing transitions being -1.
+	for w, word := range words {
+		state := int32(0)
+		for i := 0; i < len(word); i++ {
+			next := a.delta[state<<8|int32(word[i])]
+			if next < 0 {
+				next = int32(len(a.output))
+				a.delta[state<<8|int32(word[i])] = next
+				a.output = append(a.output, -1)
+				a.word = append(a.word, -1)
+				for b := 0; b < 256; b++ {
+					a.delta = append(a.delta, -1)
+				}
+			}
+			state = next
+		}
+		a.output[state] = int32(w)
+		a.word[state] = int32(w)
+	}
+
+	// Visit the trie breadth first, so that the failure state of every state, the longest proper suffix of its word
+	// in the trie, is complete before the state itself. Missing transitions then follow the failure state.
+	a.fail = make([]int32, len(a.output))
+	queue := []int32{0}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		for b := int32(0); b < 256; b++ {
+			next := a.delta[state<<8|b]
+			switch {
+			case next > 0: // A child in the trie, the only transitions set before visiting the state.
+				if state != 0 {
+					a.fail[next] = a.delta[a.fail[state]<<8|b]
+				}
+				if a.output[next] < 0 {
+					a.output[next] = a.output[a.fail[next]]
+				}
+				queue = append(queue, next)
+			case state == 0:
+				a.delta[b] = 0
+			default:
+				a.delta[state<<8|b] = a.delta[a.fail[state]<<8|b]
+			}
+		}
+	}
+
+	return a
+}
+
+// match is a word of the lexicon, or a digit, found in a line.
+type match struct {
+	word  string
+	pos   int // Byte offset of the start of the word in the line.
+	value int
+}
+
+// scanner finds the first and last digits of lines, numerical or spelled out with a lexicon.
+// It scans lines forward for the first digit and backward for the last one, stopping as soon as each is known,
+// so that overlapping words like "oneight" are both found.
+type scanner struct {
+	forward  *automaton // Matches the words.
+	backward *automaton // Matches the reversed words, on reversed lines.
+	values   []int      // Value of each word, by index in the words of the automata.
+	maxLen   int        // Length of the longest word.
+}
+
+// newScanner builds a scanner for the words of the lexicon and the digits from 0 to 9.
+func newScanner(lex lexicon) *scanner {
+	words, reversed, values := []string{}, []string{}, []int{}
+	add := func(word string, value int) {
+		words = append(words, word)
+		values = append(values, value)
+
+		rev := []byte(word)
+		for i, j := 0, len(rev)-1; i < j; i, j = i+1, j-1 {
+			rev[i], rev[j] = rev[j], rev[i]
+		}
+		reversed = append(reversed, string(rev))
+	}
+
+	for digit := 0; digit <= 9; digit++ {
+		add(strconv.Itoa(digit), digit)
+	}
+	for word, value := range lex {
+		add(word, value)
+	}
+
+	sc := &scanner{
+		forward:  newAutomaton(words),
+		backward: newAutomaton(reversed),
+		values:   values,
+	}
+	for _, word := range words {
+		sc.maxLen = max(sc.maxLen, len(word))
+	}
+
+	return sc
+}
+
+// first returns the match starting first in the line, the longest one if several start at the same position,
+// and false if there is none.
+func (sc *scanner) first(line string) (match, bool) {
+	a := sc.forward
+
+	var best match
+	found := false
+	state := int32(0)
+	for i := 0; i < len(line); i++ {
+		// Words ending from now on start after the best match.
+		if found && i >= best.pos+sc.maxLen {
+			break
+		}
+
+		state = a.delta[state<<8|int32(line[i])]
+		if w := a.output[state]; w >= 0 {
+			// The longest word ending here is the one starting first. Ending later, it replaces
+			// a match starting at the same position as it is longer.
+			if pos := i - len(a.words[w]) + 1; !found || pos <= best.pos {
+				best = match{word: a.words[w], pos: pos, value: sc.values[w]}
+				found = true
+			}
+		}
+	}
+
+	return best, found
+}
+
+// last returns the match starting last in the line, the longest one if several start at the same position,
+// and false if there is none.
+func (sc *scanner) last(line string) (match, bool) {
+	a := sc.backward
+
+	// In the reversed line, the first word to end is the one starting last in the line.
+	state := int32(0)
+	for i := len(line) - 1; i >= 0; i-- {
+		state = a.delta[state<<8|int32(line[i])]
+		if w := a.output[state]; w >= 0 {
+			return match{word: sc.forward.words[w], pos: i, value: sc.values[w]}, true
+		}
+	}
+
+	return match{}, false
+}
+
+// all returns every match in the line, including words overlapping or contained in others,
+// ordered by end position then from the longest to the shortest.
+func (sc *scanner) all(line string) []match {
+	a := sc.forward
+
+	matches := []match{}
+	state := int32(0)
+	for i := 0; i < len(line); i++ {
+		state = a.delta[state<<8|int32(line[i])]
+		for suffix := state; suffix != 0; suffix = a.fail[suffix] {
+			if w := a.word[suffix]; w >= 0 {
+				matches = append(matches, match{word: a.words[w], pos: i - len(a.words[w]) + 1, value: sc.values[w]})
+			}
+		}
+	}
+
+	return matches
+}
+
+// firstLast returns the first and last matches of the line, and false if there is none.
+func (sc *scanner) firstLast(line string) (match, match, bool) {
+	first, found := sc.first(line)
+	if !found {
+		return match{}, match{}, false
+	}
+
+	// A line with a first match has a last one, which may be the same.
+	last, _ := sc.last(line)
+
+	return first, last, true
+}
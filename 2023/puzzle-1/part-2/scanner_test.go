@@ -0,0 +1,157 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func mustLexicon(t testing.TB, names string) lexicon {
+	t.Helper()
+
+	lex, err := loadLexicon(names, "")
+	if err != nil {
+		t.Fatalf("loadLexicon(%q): %v", names, err)
+	}
+
+	return lex
+}
+
+func TestFirstLastDigits(t *testing.T) {
+	tests := []struct {
+		lexicons string
+		line     string
+		want     [2]int
+	}{
+		{"en", "1abc2", [2]int{1, 2}},
+		{"en", "oneight", [2]int{1, 8}},
+		{"en", "twone", [2]int{2, 1}},
+		{"en", "eightwo", [2]int{8, 2}},
+		{"en", "xtwonex", [2]int{2, 1}},
+		{"en", "7pqrstsixteen", [2]int{7, 6}},
+		{"en", "sevenine", [2]int{7, 9}},
+		{"en", "treb7uchet", [2]int{7, 7}},
+		{"fr", "huitrois", [2]int{8, 3}},
+		{"fr", "unedeux", [2]int{1, 2}},
+		{"de", "zweins", [2]int{2, 1}},
+		{"de", "fünfacht", [2]int{5, 8}},
+		{"es", "dosiete", [2]int{2, 7}},
+		{"es", "cuatrocho", [2]int{4, 8}},
+		{"en,fr,de,es", "sixtrois4einsnueve", [2]int{6, 9}},
+		{"en,fr,de,es", "uneightwo", [2]int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		lex := mustLexicon(t, tt.lexicons)
+
+		got, err := findFirstLastDigits(tt.line, newScanner(lex))
+		if err != nil || got != tt.want {
+			t.Errorf("%s: scanner on %q = %v, %v, want %v", tt.lexicons, tt.line, got, err, tt.want)
+		}
+
+		naive, errNaive := naiveFirstLastDigits(tt.line, lex)
+		if errNaive != nil || naive != tt.want {
+			t.Errorf("%s: naive on %q = %v, %v, want %v", tt.lexicons, tt.line, naive, errNaive, tt.want)
+		}
+	}
+}
+
+func TestNoDigits(t *testing.T) {
+	lex := mustLexicon(t, "en")
+	for _, line := range []string{"", "abc", "onf", "nin"} {
+		if _, err := findFirstLastDigits(line, newScanner(lex)); err == nil {
+			t.Errorf("scanner found digits in %q", line)
+		}
+	}
+}
+
+// TestScannerMatchesNaive cross-checks the scanner against the original approach on generated lines,
+// which mix words of every lexicon, often overlapping.
+func TestScannerMatchesNaive(t *testing.T) {
+	for _, names := range []string{"en", "fr", "de", "es", "en,fr,de,es"} {
+		lex := mustLexicon(t, names)
+		sc := newScanner(lex)
+
+		for _, line := range generateLines(lex, 5000) {
+			want, errNaive := naiveFirstLastDigits(line, lex)
+			got, err := findFirstLastDigits(line, sc)
+			if err != nil || errNaive != nil || got != want {
+				t.Fatalf("%s: on %q, scanner = %v, %v, naive = %v, %v", names, line, got, err, want, errNaive)
+			}
+		}
+	}
+}
+
+func TestAllMatches(t *testing.T) {
+	sc := newScanner(mustLexicon(t, "en"))
+
+	words := []string{}
+	for _, m := range sc.all("xoneightwo3") {
+		words = append(words, m.word)
+	}
+
+	if got, want := strings.Join(words, " "), "one eight two 3"; got != want {
+		t.Errorf("all = %q, want %q", got, want)
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int64
+		ok   bool
+	}{
+		{"512", 512, true},
+		{"2k", 2 << 10, true},
+		{"3M", 3 << 20, true},
+		{"1G", 1 << 30, true},
+		{"", 0, false},
+		{"M", 0, false},
+		{"-1K", 0, false},
+		{"12X", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSize(tt.s)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("parseSize(%q) = %d, %v", tt.s, got, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), `"`+tt.s+`"`) {
+			t.Errorf("parseSize(%q) error does not quote the argument: %v", tt.s, err)
+		}
+	}
+}
+
+func benchmarkLines(b *testing.B) (lexicon, []string) {
+	lex := mustLexicon(b, "en")
+	lines := generateLines(lex, 1<<12)
+
+	size := 0
+	for _, line := range lines {
+		size += len(line)
+	}
+	b.SetBytes(int64(size / len(lines)))
+	b.ResetTimer()
+
+	return lex, lines
+}
+
+func BenchmarkNaive(b *testing.B) {
+	lex, lines := benchmarkLines(b)
+	for i := 0; i < b.N; i++ {
+		if _, err := naiveFirstLastDigits(lines[i%len(lines)], lex); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkScanner(b *testing.B) {
+	lex, lines := benchmarkLines(b)
+	sc := newScanner(lex)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := findFirstLastDigits(lines[i%len(lines)], sc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
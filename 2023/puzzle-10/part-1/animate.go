@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+	"github.com/maaxleq/advent-of-code-2023/lib/term"
+)
+
+// loopFrameStride is the number of pipes followed between two frames of the animation.
+const loopFrameStride = 50
+
+// pipeRunes holds the box drawing character of each tile type, indexed by tile.
+var pipeRunes = []rune{' ', 'S', '│', '─', '└', '┘', '┌', '┐'}
+
+// animatePipe draws on the screen the network with the pipes followed so far, the current one being at x, y.
+func (n network) animatePipe(screen *term.Screen, visited grid.Grid[bool], x, y, distance int) {
+	term.Draw(screen, n.Grid, func(cx, cy int, t tile) term.Cell {
+		switch {
+		case cx == x && cy == y:
+			return term.Cell{Rune: pipeRunes[t], Color: term.BrightRed}
+		case t == start:
+			return term.Cell{Rune: pipeRunes[t], Color: term.BrightYellow}
+		case visited.At(cx, cy):
+			return term.Cell{Rune: pipeRunes[t], Color: term.BrightGreen}
+		default:
+			return term.Cell{Rune: pipeRunes[t], Color: term.Gray}
+		}
+	}, fmt.Sprintf("%d pipes followed, at (%d, %d)", distance, x, y))
+}
@@ -1,11 +1,15 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"log"
-	"os"
 	"slices"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+	"github.com/maaxleq/advent-of-code-2023/lib/term"
+	"github.com/maaxleq/advent-of-code-2023/lib/trace"
 )
 
 const inputFile = "input.txt"
@@ -25,45 +29,38 @@ const (
 	southWest
 )
 
+// tileChars holds the character of each tile type in the input, indexed by tile.
+const tileChars = ".S|-LJF7"
+
 // network represents a grid of tiles.
-type network [][]tile
+type network struct {
+	grid.Grid[tile]
+}
 
 // findStart locates the starting point in the network.
 // It returns the x and y coordinates of the start tile and an error if the start tile is not found.
 func (n network) findStart() (int, int, error) {
-	for y, line := range n {
-		for x, t := range line {
-			if t == start {
-				return x, y, nil
-			}
-		}
+	x, y, found := n.Find(func(t tile) bool {
+		return t == start
+	})
+	if !found {
+		return 0, 0, fmt.Errorf("unable to find start")
 	}
 
-	return 0, 0, fmt.Errorf("unable to find start")
-}
-
-// size returns the width and height of the network.
-func (n network) size() (int, int) {
-	if len(n) == 0 {
-		return 0, 0
-	}
-
-	return len(n[0]), len(n)
+	return x, y, nil
 }
 
 // getMaxTravelDistance computes the maximum distance that can be navigated from the start tile.
 // It returns the maximum distance and an error if the start tile is not found or navigation is not possible.
-func (n network) getMaxTravelDistance() (int, error) {
+// The pipes followed are animated on the screen, unless it is nil.
+func (n network) getMaxTravelDistance(screen *term.Screen) (int, error) {
 	x, y, errStart := n.findStart()
 	if errStart != nil {
 		return 0, fmt.Errorf("cannot navigate network: %w", errStart)
 	}
 
-	width, height := n.size()
-	visited := make([][]bool, height)
-	for i := range visited {
-		visited[i] = make([]bool, width)
-	}
+	width, height := n.Width(), n.Height()
+	visited := grid.New[bool](width, height)
 
 	followPipe := func(x, y, dx, dy, distance int) int {
 		maxDistance := distance
@@ -73,18 +70,18 @@ func (n network) getMaxTravelDistance() (int, error) {
 			y += dy
 
 			// Check if out of bounds
-			if x < 0 || x >= width || y < 0 || y >= height {
+			if !n.InBounds(x, y) {
 				break
 			}
 
-			currentTile := n[y][x]
+			currentTile := n.At(x, y)
 
 			// Check if it's a looping tile or an empty tile
-			if currentTile == empty || visited[y][x] {
+			if currentTile == empty || visited.At(x, y) {
 				break
 			}
 
-			visited[y][x] = true
+			visited.Set(x, y, true)
 			distance++
 
 			// Update maxDistance
@@ -127,6 +124,11 @@ func (n network) getMaxTravelDistance() (int, error) {
 					dy = 1
 				}
 			}
+
+			trace.Emit("pipe", "x", x, "y", y, "tile", string(tileChars[currentTile]), "dx", dx, "dy", dy)
+			if screen != nil && distance%loopFrameStride == 0 {
+				n.animatePipe(screen, visited, x, y, distance)
+			}
 		}
 
 		return maxDistance
@@ -134,16 +136,16 @@ func (n network) getMaxTravelDistance() (int, error) {
 
 	// Check possible initial directions from the start
 	maxDist := 0
-	if y+1 < height && !slices.Contains([]tile{horizontal, northEast, northWest, empty}, n[y+1][x]) { // Down
+	if y+1 < height && !slices.Contains([]tile{horizontal, northEast, northWest, empty}, n.At(x, y+1)) { // Down
 		maxDist = max(maxDist, followPipe(x, y, 0, 1, 0))
 	}
-	if y-1 >= 0 && !slices.Contains([]tile{horizontal, northEast, northWest, empty}, n[y-1][x]) { // Up
+	if y-1 >= 0 && !slices.Contains([]tile{horizontal, northEast, northWest, empty}, n.At(x, y-1)) { // Up
 		maxDist = max(maxDist, followPipe(x, y, 0, -1, 0))
 	}
-	if x+1 < width && !slices.Contains([]tile{vertical, northWest, southWest, empty}, n[y][x+1]) { // Right
+	if x+1 < width && !slices.Contains([]tile{vertical, northWest, southWest, empty}, n.At(x+1, y)) { // Right
 		maxDist = max(maxDist, followPipe(x, y, 1, 0, 0))
 	}
-	if x-1 >= 0 && !slices.Contains([]tile{vertical, northEast, southEast, empty}, n[y][x-1]) { // Left
+	if x-1 >= 0 && !slices.Contains([]tile{vertical, northEast, southEast, empty}, n.At(x-1, y)) { // Left
 		maxDist = max(maxDist, followPipe(x, y, -1, 0, 0))
 	}
 
@@ -186,52 +188,28 @@ func tileFromRune(r rune) (tile, error) {
 // parseNetwork converts a slice of string lines into a network.
 // It returns the parsed network and an error if any line contains invalid tiles.
 func parseNetwork(lines []string) (network, error) {
-	net := network{}
-
-	for _, line := range lines {
-		netLine := []tile{}
-		for _, r := range line {
-			tile, errTile := tileFromRune(r)
-			if errTile != nil {
-				return nil, fmt.Errorf("cannot parse network: %w", errTile)
-			}
-
-			netLine = append(netLine, tile)
-		}
-		net = append(net, netLine)
+	g, errGrid := grid.Parse(lines, tileFromRune)
+	if errGrid != nil {
+		return network{}, fmt.Errorf("cannot parse network: %w", errGrid)
 	}
 
-	return net, nil
-}
-
-// readFileLines reads a file and returns its contents as an array of strings.
-func readFileLines(filePath string) ([]string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	return lines, scanner.Err()
+	return network{g}, nil
 }
 
 func main() {
-	lines, errRead := readFileLines(inputFile)
+	lines, errRead := input.ReadLines(inputFile)
 	if errRead != nil {
 		log.Fatal(errRead)
 	}
 
 	n, _ := parseNetwork(lines)
 
-	distance, errNav := n.getMaxTravelDistance()
+	screen := term.Start()
+	distance, errNav := n.getMaxTravelDistance(screen)
+	screen.Stop()
 	if errNav != nil {
 		log.Fatal(errNav)
 	}
 
-	fmt.Println(distance)
+	solution.Print(distance)
 }
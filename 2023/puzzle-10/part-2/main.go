@@ -1,11 +1,15 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"log"
-	"os"
 	"slices"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/render"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+	"github.com/maaxleq/advent-of-code-2023/lib/trace"
 )
 
 const inputFile = "input.txt"
@@ -24,30 +28,25 @@ const (
 	southWest
 )
 
+// tileChars holds the character of each tile type in the input, indexed by tile.
+const tileChars = ".S|-LJF7"
+
 // network represents a grid of tiles.
-type network [][]tile
+type network struct {
+	grid.Grid[tile]
+}
 
 // findStart locates the starting point in the network.
 // It returns the x and y coordinates of the start tile and an error if the start tile is not found.
 func (n network) findStart() (int, int, error) {
-	for y, line := range n {
-		for x, t := range line {
-			if t == start {
-				return x, y, nil
-			}
-		}
-	}
-
-	return 0, 0, fmt.Errorf("unable to find start")
-}
-
-// size returns the width and height of the network.
-func (n network) size() (int, int) {
-	if len(n) == 0 {
-		return 0, 0
+	x, y, found := n.Find(func(t tile) bool {
+		return t == start
+	})
+	if !found {
+		return 0, 0, fmt.Errorf("unable to find start")
 	}
 
-	return len(n[0]), len(n)
+	return x, y, nil
 }
 
 // getArea calculates the area enclosed by the pipes in the network.
@@ -58,14 +57,8 @@ func (n network) getArea() (int, error) {
 		return 0, fmt.Errorf("cannot navigate network: %w", errStart)
 	}
 
-	width, height := n.size()
-	loopBoundary := make([][]tile, height)
-	for i := range loopBoundary {
-		loopBoundary[i] = make([]tile, width)
-		for j := range loopBoundary[i] {
-			loopBoundary[i][j] = empty
-		}
-	}
+	width, height := n.Width(), n.Height()
+	loopBoundary := grid.New[tile](width, height) // Every tile starts empty, the zero tile.
 
 	followPipe := func(x, y, dx, dy int) {
 		for {
@@ -73,18 +66,18 @@ func (n network) getArea() (int, error) {
 			y += dy
 
 			// Check if out of bounds
-			if x < 0 || x >= width || y < 0 || y >= height {
+			if !n.InBounds(x, y) {
 				break
 			}
 
-			currentTile := n[y][x]
+			currentTile := n.At(x, y)
 
 			// Check if it's a looping tile or an empty tile
-			if currentTile == empty || loopBoundary[y][x] != empty {
+			if currentTile == empty || loopBoundary.At(x, y) != empty {
 				break
 			}
 
-			loopBoundary[y][x] = currentTile
+			loopBoundary.Set(x, y, currentTile)
 
 			// Change direction based on the type of pipe
 			switch currentTile {
@@ -121,40 +114,50 @@ func (n network) getArea() (int, error) {
 					dy = 1
 				}
 			}
+
+			trace.Emit("pipe", "x", x, "y", y, "tile", string(tileChars[currentTile]), "dx", dx, "dy", dy)
 		}
 	}
 
 	// Check possible initial directions from the start
-	if y+1 < height && !slices.Contains([]tile{horizontal, northEast, northWest, empty}, n[y+1][x]) { // Down
+	if y+1 < height && !slices.Contains([]tile{horizontal, northEast, northWest, empty}, n.At(x, y+1)) { // Down
 		followPipe(x, y, 0, 1)
 	}
-	if y-1 >= 0 && !slices.Contains([]tile{horizontal, northEast, northWest, empty}, n[y-1][x]) { // Up
+	if y-1 >= 0 && !slices.Contains([]tile{horizontal, northEast, northWest, empty}, n.At(x, y-1)) { // Up
 		followPipe(x, y, 0, -1)
 	}
-	if x+1 < width && !slices.Contains([]tile{vertical, northWest, southWest, empty}, n[y][x+1]) { // Right
+	if x+1 < width && !slices.Contains([]tile{vertical, northWest, southWest, empty}, n.At(x+1, y)) { // Right
 		followPipe(x, y, 1, 0)
 	}
-	if x-1 >= 0 && !slices.Contains([]tile{vertical, northEast, southEast, empty}, n[y][x-1]) { // Left
+	if x-1 >= 0 && !slices.Contains([]tile{vertical, northEast, southEast, empty}, n.At(x-1, y)) { // Left
 		followPipe(x, y, -1, 0)
 	}
 
 	// Applying the even-odd rule to count the surface area
 	surfaceArea := 0
+	enclosed := grid.New[bool](width, height)
 
 	for i := 0; i < height; i++ {
 		for j := 0; j < width; j++ {
 			inside := false
 			for k := 0; k+j < width && k+i < height; k++ {
-				if slices.Contains([]tile{horizontal, vertical, northWest, southEast}, loopBoundary[i+k][j+k]) {
+				if slices.Contains([]tile{horizontal, vertical, northWest, southEast}, loopBoundary.At(j+k, i+k)) {
 					inside = !inside
 				}
 			}
-			if inside && loopBoundary[i][j] == empty {
+			if inside && loopBoundary.At(j, i) == empty {
 				surfaceArea++
+				enclosed.Set(j, i, true)
 			}
 		}
 	}
 
+	if render.Enabled() {
+		if errRender := n.renderLoop(loopBoundary, enclosed); errRender != nil {
+			return 0, errRender
+		}
+	}
+
 	return surfaceArea, nil
 }
 
@@ -186,42 +189,16 @@ func tileFromRune(r rune) (tile, error) {
 // parseNetwork converts a slice of string lines into a network.
 // It returns the parsed network and an error if any line contains invalid tiles.
 func parseNetwork(lines []string) (network, error) {
-	net := network{}
-
-	for _, line := range lines {
-		netLine := []tile{}
-		for _, r := range line {
-			tile, errTile := tileFromRune(r)
-			if errTile != nil {
-				return nil, fmt.Errorf("cannot parse network: %w", errTile)
-			}
-
-			netLine = append(netLine, tile)
-		}
-		net = append(net, netLine)
+	g, errGrid := grid.Parse(lines, tileFromRune)
+	if errGrid != nil {
+		return network{}, fmt.Errorf("cannot parse network: %w", errGrid)
 	}
 
-	return net, nil
-}
-
-// readFileLines reads a file and returns its contents as an array of strings.
-func readFileLines(filePath string) ([]string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	return lines, scanner.Err()
+	return network{g}, nil
 }
 
 func main() {
-	lines, errRead := readFileLines(inputFile)
+	lines, errRead := input.ReadLines(inputFile)
 	if errRead != nil {
 		log.Fatal(errRead)
 	}
@@ -233,5 +210,5 @@ func main() {
 		log.Fatal(errNav)
 	}
 
-	fmt.Println(area)
+	solution.Print(area)
 }
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+	"github.com/maaxleq/advent-of-code-2023/lib/render"
+)
+
+// renderScale is the size in pixels of a tile in rendered images.
+const renderScale = 4
+
+// Colors of the rendered network, as indices in networkPalette.
+const (
+	colorOutside uint8 = iota
+	colorInside
+	colorLoop
+	colorJunk // Pipes which are not part of the loop.
+)
+
+// networkPalette holds the colors of the rendered network.
+var networkPalette = color.Palette{
+	colorOutside: color.RGBA{R: 0x0f, G: 0x0f, B: 0x23, A: 0xff},
+	colorInside:  color.RGBA{R: 0x00, G: 0xcc, B: 0x00, A: 0xff},
+	colorLoop:    color.RGBA{R: 0xff, G: 0xff, B: 0x66, A: 0xff},
+	colorJunk:    color.RGBA{R: 0x44, G: 0x44, B: 0x55, A: 0xff},
+}
+
+// renderLoop draws the network with the tiles of the loop, the tiles enclosed by it and the other tiles in different colors.
+func (n network) renderLoop(loopBoundary grid.Grid[tile], enclosed grid.Grid[bool]) error {
+	img := render.Frame(n.Grid, renderScale, networkPalette, func(x, y int, t tile) uint8 {
+		switch {
+		case loopBoundary.At(x, y) != empty:
+			return colorLoop
+		case enclosed.At(x, y):
+			return colorInside
+		case t != empty:
+			return colorJunk
+		default:
+			return colorOutside
+		}
+	})
+
+	return render.WritePNG("day-10-loop", img)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/geom"
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/render"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+)
+
+const inputFile = "input.txt"
+
+// universe represents a 2D grid of tiles.
+type universe struct {
+	grid.Grid[tile]
+}
+
+// getEmptyRowsCols identifies the indices of entirely empty rows and columns in the universe.
+// It returns two slices of integers, the first representing row indices and the second column indices.
+func (u universe) getEmptyRowsCols() ([]int, []int) {
+	emptyRows := []int{}
+	emptyCols := []int{}
+
+	for y := 0; y < u.Height(); y++ {
+		if !slices.Contains(u.Row(y), galaxy) {
+			emptyRows = append(emptyRows, y)
+		}
+	}
+
+	for x := 0; x < u.Width(); x++ {
+		if !slices.Contains(u.Col(x), galaxy) {
+			emptyCols = append(emptyCols, x)
+		}
+	}
+
+	return emptyRows, emptyCols
+}
+
+// getGalaxiesCoordinates returns the positions of the galaxies in the universe.
+func (u universe) getGalaxiesCoordinates() []geom.Point {
+	galaxies := []geom.Point{}
+
+	for y := 0; y < u.Height(); y++ {
+		for x, t := range u.Row(y) {
+			if t == galaxy {
+				galaxies = append(galaxies, geom.Point{X: x, Y: y})
+			}
+		}
+	}
+
+	return galaxies
+}
+
+// getDistinctCoordinatePairs computes all distinct pairs of galaxy coordinates in the universe.
+// It returns a slice of pairs of positions, each represented as [2]geom.Point.
+func (u universe) getDistinctCoordinatePairs() [][2]geom.Point {
+	galaxies := u.getGalaxiesCoordinates()
+	pairs := [][2]geom.Point{}
+
+	for i := 0; i < len(galaxies); i++ {
+		for j := i + 1; j < len(galaxies); j++ {
+			pairs = append(pairs, [2]geom.Point{
+				galaxies[i], galaxies[j],
+			})
+		}
+	}
+
+	return pairs
+}
+
+// tile represents the state of a single cell in the universe.
+type tile int
+
+// Constants representing the different types of tiles in the universe.
+const (
+	galaxy tile = iota
+	empty
+)
+
+// expandUniverse duplicates tiles corresponding to empty rows and columns in the universe,
+// effectively expanding it. It returns a new, expanded universe.
+func expandUniverse(u universe) universe {
+	emptyRows, emptyCols := u.getEmptyRowsCols()
+	expanded := grid.New[tile](u.Width()+len(emptyCols), u.Height()+len(emptyRows))
+
+	expandedY := 0
+	for y := 0; y < u.Height(); y++ {
+		line := []tile{}
+		for x, t := range u.Row(y) {
+			line = append(line, t)
+			if slices.Contains(emptyCols, x) {
+				line = append(line, t)
+			}
+		}
+
+		copy(expanded.Row(expandedY), line)
+		expandedY++
+		if slices.Contains(emptyRows, y) {
+			copy(expanded.Row(expandedY), line)
+			expandedY++
+		}
+	}
+
+	return universe{expanded}
+}
+
+// parseUniverse converts a slice of string lines into a universe.
+// It returns the parsed universe and an error if the parsing fails.
+func parseUniverse(lines []string) (universe, error) {
+	g, errGrid := grid.Parse(lines, func(r rune) (tile, error) {
+		switch r {
+		case '#':
+			return galaxy, nil
+		case '.':
+			return empty, nil
+		default:
+			return empty, fmt.Errorf("invalid tile %c", r)
+		}
+	})
+	if errGrid != nil {
+		return universe{}, fmt.Errorf("cannot parse universe: %w", errGrid)
+	}
+
+	return universe{g}, nil
+}
+
+// pairDistance calculates the Manhattan distance between a pair of galaxy coordinates.
+func pairDistance(pair [2]geom.Point) int {
+	return geom.Manhattan(pair[0], pair[1])
+}
+
+func main() {
+	lines, errRead := input.ReadLines(inputFile)
+	if errRead != nil {
+		log.Fatal(errRead)
+	}
+
+	u, errParse := parseUniverse(lines)
+	if errParse != nil {
+		log.Fatal(errParse)
+	}
+
+	uExp := expandUniverse(u)
+
+	if render.Enabled() {
+		if errRender := uExp.renderExpanded(); errRender != nil {
+			log.Fatal(errRender)
+		}
+	}
+
+	distinctCoordPairs := uExp.getDistinctCoordinatePairs()
+
+	sum := 0
+	for _, pair := range distinctCoordPairs {
+		sum += pairDistance(pair)
+	}
+
+	solution.Print(sum)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"image/color"
+	"slices"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/render"
+)
+
+// renderScale is the size in pixels of a tile in rendered images.
+const renderScale = 3
+
+// Colors of the rendered universe, as indices in universePalette.
+const (
+	colorEmpty uint8 = iota
+	colorExpanded
+	colorGalaxy
+)
+
+// universePalette holds the colors of the rendered universe.
+var universePalette = color.Palette{
+	colorEmpty:    color.RGBA{R: 0x0f, G: 0x0f, B: 0x23, A: 0xff},
+	colorExpanded: color.RGBA{R: 0x22, G: 0x22, B: 0x55, A: 0xff},
+	colorGalaxy:   color.RGBA{R: 0xff, G: 0xff, B: 0x66, A: 0xff},
+}
+
+// renderExpanded draws an expanded universe, highlighting its empty rows and columns, which are the expanded ones.
+func (u universe) renderExpanded() error {
+	emptyRows, emptyCols := u.getEmptyRowsCols()
+
+	img := render.Frame(u.Grid, renderScale, universePalette, func(x, y int, t tile) uint8 {
+		switch {
+		case t == galaxy:
+			return colorGalaxy
+		case slices.Contains(emptyRows, y) || slices.Contains(emptyCols, x):
+			return colorExpanded
+		default:
+			return colorEmpty
+		}
+	})
+
+	return render.WritePNG("day-11-expanded-universe", img)
+}
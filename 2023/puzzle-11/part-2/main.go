@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/geom"
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+)
+
+const inputFile = "input.txt"
+
+// expansionMultiplier is used to calculate the expanded distance between galaxy pairs.
+const expansionMultiplier int = 1000000
+
+// universe represents a 2D grid of tiles.
+type universe struct {
+	grid.Grid[tile]
+}
+
+// getEmptyRowsCols identifies the indices of entirely empty rows and columns in the universe.
+// It returns two slices of integers, the first representing row indices and the second column indices.
+func (u universe) getEmptyRowsCols() ([]int, []int) {
+	emptyRows := []int{}
+	emptyCols := []int{}
+
+	for y := 0; y < u.Height(); y++ {
+		if !slices.Contains(u.Row(y), galaxy) {
+			emptyRows = append(emptyRows, y)
+		}
+	}
+
+	for x := 0; x < u.Width(); x++ {
+		if !slices.Contains(u.Col(x), galaxy) {
+			emptyCols = append(emptyCols, x)
+		}
+	}
+
+	return emptyRows, emptyCols
+}
+
+// getGalaxiesCoordinates returns the positions of the galaxies in the universe.
+func (u universe) getGalaxiesCoordinates() []geom.Point {
+	galaxies := []geom.Point{}
+
+	for y := 0; y < u.Height(); y++ {
+		for x, t := range u.Row(y) {
+			if t == galaxy {
+				galaxies = append(galaxies, geom.Point{X: x, Y: y})
+			}
+		}
+	}
+
+	return galaxies
+}
+
+// getDistinctCoordinatePairs computes all distinct pairs of galaxy coordinates in the universe.
+// It returns a slice of pairs of positions, each represented as [2]geom.Point.
+func (u universe) getDistinctCoordinatePairs() [][2]geom.Point {
+	galaxies := u.getGalaxiesCoordinates()
+	pairs := [][2]geom.Point{}
+
+	for i := 0; i < len(galaxies); i++ {
+		for j := i + 1; j < len(galaxies); j++ {
+			pairs = append(pairs, [2]geom.Point{
+				galaxies[i], galaxies[j],
+			})
+		}
+	}
+
+	return pairs
+}
+
+// tile represents the state of a single cell in the universe.
+type tile int
+
+// Constants representing the different types of tiles in the universe.
+const (
+	galaxy tile = iota
+	empty
+)
+
+// parseUniverse converts a slice of string lines into a universe.
+// It returns the parsed universe and an error if the parsing fails.
+func parseUniverse(lines []string) (universe, error) {
+	g, errGrid := grid.Parse(lines, func(r rune) (tile, error) {
+		switch r {
+		case '#':
+			return galaxy, nil
+		case '.':
+			return empty, nil
+		default:
+			return empty, fmt.Errorf("invalid tile %c", r)
+		}
+	})
+	if errGrid != nil {
+		return universe{}, fmt.Errorf("cannot parse universe: %w", errGrid)
+	}
+
+	return universe{g}, nil
+}
+
+// pairDistanceWithExpansion calculates the expanded distance between a pair of galaxy coordinates.
+// It takes into account empty rows and columns that expand the distance.
+func pairDistanceWithExpansion(pair [2]geom.Point, emptyRows, emptyCols []int) int {
+	rowExpCount, colExpCount := 0, 0
+
+	p1, p2 := pair[0], pair[1]
+
+	for _, row := range emptyRows {
+		if row <= max(p1.Y, p2.Y) && row >= min(p1.Y, p2.Y) {
+			rowExpCount++
+		}
+	}
+
+	for _, col := range emptyCols {
+		if col <= max(p1.X, p2.X) && col >= min(p1.X, p2.X) {
+			colExpCount++
+		}
+	}
+
+	return geom.Manhattan(p1, p2) + (expansionMultiplier-1)*(rowExpCount+colExpCount)
+}
+
+func main() {
+	lines, errRead := input.ReadLines(inputFile)
+	if errRead != nil {
+		log.Fatal(errRead)
+	}
+
+	u, errParse := parseUniverse(lines)
+	if errParse != nil {
+		log.Fatal(errParse)
+	}
+
+	distinctCoordPairs := u.getDistinctCoordinatePairs()
+	emptyRows, emptyCols := u.getEmptyRowsCols()
+
+	sum := 0
+	for _, pair := range distinctCoordPairs {
+		sum += pairDistanceWithExpansion(pair, emptyRows, emptyCols)
+	}
+
+	solution.Print(sum)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/repl"
+)
+
+// parseRecord parses a condition record typed in the REPL, either quoted or as two arguments.
+func parseRecord(args []string) ([]rune, []int, error) {
+	record := strings.Join(args, " ")
+	if len(strings.Fields(record)) != 2 {
+		return nil, nil, fmt.Errorf("expected a condition and its groups, like \"?###???????? 3,2,1\"")
+	}
+
+	return parseConditionAndGroups(record)
+}
+
+// replCommands returns the commands of the REPL, evaluated against the lines of the input.
+func replCommands(lines []string) []repl.Command {
+	return []repl.Command{
+		{
+			Name: "arrangements",
+			Args: "<condition> <groups>",
+			Help: "count the arrangements of a condition record",
+			Run: func(args []string) (string, error) {
+				condition, groups, errParse := parseRecord(args)
+				if errParse != nil {
+					return "", errParse
+				}
+
+				return strconv.Itoa(countArrangements(condition, groups)), nil
+			},
+		},
+		{
+			Name: "line",
+			Args: "<n>",
+			Help: "count the arrangements of the record on line n of the input",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 1); errArgs != nil {
+					return "", errArgs
+				}
+
+				n, errN := strconv.Atoi(args[0])
+				if errN != nil || n < 1 || n > len(lines) {
+					return "", fmt.Errorf("invalid line number, the input has %d lines: %s", len(lines), args[0])
+				}
+
+				condition, groups, errParse := parseConditionAndGroups(lines[n-1])
+				if errParse != nil {
+					return "", errParse
+				}
+
+				return fmt.Sprintf("%s: %d", lines[n-1], countArrangements(condition, groups)), nil
+			},
+		},
+	}
+}
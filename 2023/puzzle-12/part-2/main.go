@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/memo"
+	"github.com/maaxleq/advent-of-code-2023/lib/repl"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+	"github.com/maaxleq/advent-of-code-2023/lib/trace"
+)
+
+const inputFile = "input.txt"
+
+// scanState is a position in the scan of a condition record from left to right: the index of the next spring,
+// the index of the next group of damaged springs, and the length of the run of damaged springs in progress.
+type scanState struct {
+	index, groupIndex, run int
+}
+
+// countArrangements counts the number of valid arrangements of damaged springs.
+// It memoizes the count of every scan state, which many arrangements share.
+func countArrangements(condition []rune, groups []int) int {
+	cache := memo.New[scanState, int]()
+	count := memo.Recursive(cache, func(count func(scanState) int, s scanState) int {
+		return countArrangementsInner(condition, groups, s, count)
+	})
+
+	arrangements := count(scanState{})
+
+	if trace.Enabled() {
+		stats := cache.Stats()
+		trace.Emit("arrangements", "condition", string(condition), "count", arrangements, "hits", stats.Hits, "misses", stats.Misses)
+	}
+
+	return arrangements
+}
+
+// countArrangementsInner counts the number of valid arrangements of the springs after scan state s.
+// It calls count, its memoized self, for the following states.
+func countArrangementsInner(condition []rune, groups []int, s scanState, count func(scanState) int) int {
+	// Base case: every spring is placed, the last run must close the last group.
+	if s.index == len(condition) {
+		if (s.run == 0 && s.groupIndex == len(groups)) || (s.groupIndex == len(groups)-1 && s.run == groups[s.groupIndex]) {
+			return 1
+		}
+		return 0
+	}
+
+	permutations := 0
+	spring := condition[s.index]
+
+	// Damaged spring, or unknown status counted as damaged: extend the run if the group allows it.
+	if spring == '#' || spring == '?' {
+		if s.groupIndex < len(groups) && s.run < groups[s.groupIndex] {
+			permutations += count(scanState{index: s.index + 1, groupIndex: s.groupIndex, run: s.run + 1})
+		}
+	}
+
+	// Operational spring, or unknown status counted as operational: close the run if it completes its group.
+	if spring == '.' || spring == '?' {
+		if s.run == 0 {
+			permutations += count(scanState{index: s.index + 1, groupIndex: s.groupIndex})
+		} else if s.run == groups[s.groupIndex] {
+			permutations += count(scanState{index: s.index + 1, groupIndex: s.groupIndex + 1})
+		}
+	}
+
+	return permutations
+}
+
+// parseConditionAndGroups parses a line of input into a condition (array of runes) and a slice of groups (integers).
+// Returns an error if any part of the input cannot be parsed correctly.
+func parseConditionAndGroups(line string) ([]rune, []int, error) {
+	fields := strings.Fields(line)
+	groupsStr := strings.Split(fields[1], ",")
+	groups := []int{}
+
+	for _, groupStr := range groupsStr {
+		group, errConv := strconv.Atoi(groupStr)
+		if errConv != nil {
+			return nil, nil, fmt.Errorf("cannot parse condition and groups: %w", errConv)
+		}
+		groups = append(groups, group)
+	}
+
+	return []rune(fields[0]), groups, nil
+}
+
+// unfoldConditionAndGroups expands the given condition and groups 5 times.
+// It insert a '?' between each repetition of the condition
+func unfoldConditionAndGroups(condition []rune, groups []int) ([]rune, []int) {
+	newCondition := []rune{}
+	newGroups := []int{}
+
+	for i := 0; i < 5; i++ {
+		if i != 0 {
+			newCondition = append(newCondition, '?')
+		}
+		newCondition = append(newCondition, condition...)
+		newGroups = append(newGroups, groups...)
+	}
+
+	return newCondition, newGroups
+}
+
+func main() {
+	lines, errRead := input.ReadLines(inputFile)
+	if errRead != nil {
+		log.Fatal(errRead)
+	}
+
+	if repl.Enabled() {
+		if errRepl := repl.Run(os.Stdin, os.Stdout, "day 12 part 2", replCommands(lines)); errRepl != nil {
+			log.Fatal(errRepl)
+		}
+		return
+	}
+
+	sum := 0
+	for _, line := range lines {
+		condition, groups, errParse := parseConditionAndGroups(line)
+		if errParse != nil {
+			log.Fatal(errParse)
+		}
+
+		condition, groups = unfoldConditionAndGroups(condition, groups)
+
+		sum += countArrangements(condition, groups)
+	}
+
+	solution.Print(sum)
+}
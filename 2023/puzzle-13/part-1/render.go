@@ -0,0 +1,50 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"time"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/render"
+)
+
+// renderScale is the size in pixels of a tile in rendered images.
+const renderScale = 12
+
+// patternDelay is how long each pattern is shown in rendered animations.
+const patternDelay = 500 * time.Millisecond
+
+// Colors of the rendered patterns, as indices in patternPalette.
+const (
+	colorAsh uint8 = iota
+	colorRock
+	colorMirror
+)
+
+// patternPalette holds the colors of the rendered patterns.
+var patternPalette = color.Palette{
+	colorAsh:    color.RGBA{R: 0x0f, G: 0x0f, B: 0x23, A: 0xff},
+	colorRock:   color.RGBA{R: 0x99, G: 0x99, B: 0xaa, A: 0xff},
+	colorMirror: color.RGBA{R: 0xff, G: 0x33, B: 0x33, A: 0xff},
+}
+
+// frame draws the pattern with its line of reflection.
+func (p pattern) frame(r reflection) *image.Paletted {
+	img := render.Frame(p.Grid, renderScale, patternPalette, func(x, y int, c rune) uint8 {
+		if c == '#' {
+			return colorRock
+		}
+		return colorAsh
+	})
+
+	// The line lies between the tiles at index-1 and index, draw it across both.
+	bounds := img.Bounds()
+	line := r.index * renderScale
+	if r.direction == vertical {
+		render.FillRect(img, image.Rect(line-1, 0, line+1, bounds.Max.Y), colorMirror)
+	} else {
+		render.FillRect(img, image.Rect(0, line-1, bounds.Max.X, line+1), colorMirror)
+	}
+
+	return img
+}
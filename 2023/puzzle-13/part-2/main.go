@@ -1,11 +1,13 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"log"
-	"os"
 	"slices"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
 )
 
 const inputFile = "input.txt"
@@ -37,26 +39,24 @@ func (r reflection) value() int {
 	return r.index * 100
 }
 
-// pattern represents a 2D pattern, defined as a slice of rune slices.
-type pattern [][]rune
+// pattern represents a 2D pattern of ash and rocks.
+type pattern struct {
+	grid.Grid[rune]
+}
 
 // hasReflection checks if a given pattern has a reflection specified by the argument 'r'.
 // It returns true if the pattern contains the specified reflection, otherwise false.
 func (p pattern) hasReflection(r reflection) bool {
 	if r.direction == vertical {
-		for i := 0; i+r.index < len(p[0]) && r.index-i > 0; i++ {
-			for y := 0; y < len(p); y++ {
-				if p[y][i+r.index] != p[y][r.index-i-1] {
-					return false
-				}
+		for i := 0; i+r.index < p.Width() && r.index-i > 0; i++ {
+			if !slices.Equal(p.Col(i+r.index), p.Col(r.index-i-1)) {
+				return false
 			}
 		}
 	} else {
-		for i := 0; i+r.index < len(p) && r.index-i > 0; i++ {
-			for x := 0; x < len(p[0]); x++ {
-				if p[i+r.index][x] != p[r.index-i-1][x] {
-					return false
-				}
+		for i := 0; i+r.index < p.Height() && r.index-i > 0; i++ {
+			if !slices.Equal(p.Row(i+r.index), p.Row(r.index-i-1)) {
+				return false
 			}
 		}
 	}
@@ -69,20 +69,13 @@ func (p pattern) hasReflection(r reflection) bool {
 // It returns a slice of these modified patterns.
 func (p pattern) getAllPossibleCleanPatterns() []pattern {
 	cleanPatterns := []pattern{}
-	for y := range p {
-		for x := range p[y] {
-			cleanPattern := pattern{}
-			for i := range p {
-				var row []rune
-				for j := range p[i] {
-					row = append(row, p[i][j])
-				}
-				cleanPattern = append(cleanPattern, row)
-			}
-			if cleanPattern[y][x] == '.' {
-				cleanPattern[y][x] = '#'
+	for y := 0; y < p.Height(); y++ {
+		for x := 0; x < p.Width(); x++ {
+			cleanPattern := pattern{p.Clone()}
+			if cleanPattern.At(x, y) == '.' {
+				cleanPattern.Set(x, y, '#')
 			} else {
-				cleanPattern[y][x] = '.'
+				cleanPattern.Set(x, y, '.')
 			}
 			cleanPatterns = append(cleanPatterns, cleanPattern)
 		}
@@ -113,13 +106,13 @@ func (p pattern) findCleanReflection() (reflection, error) {
 // It returns the first found reflection and an error if no reflection is found.
 func (p pattern) findReflection(skip ...reflection) (reflection, error) {
 	possibleReflections := []reflection{}
-	for y := 1; y < len(p); y++ {
+	for y := 1; y < p.Height(); y++ {
 		possibleReflections = append(possibleReflections, reflection{
 			index:     y,
 			direction: horizontal,
 		})
 	}
-	for x := 1; x < len(p[0]); x++ {
+	for x := 1; x < p.Width(); x++ {
 		possibleReflections = append(possibleReflections, reflection{
 			index:     x,
 			direction: vertical,
@@ -137,49 +130,51 @@ func (p pattern) findReflection(skip ...reflection) (reflection, error) {
 
 // parsePatterns parses a slice of strings into a slice of patterns.
 // Each pattern is separated by an empty string in the slice.
-func parsePatterns(lines []string) []pattern {
-	currentPattern := pattern{}
+// It returns an error if the lines of a pattern are not all the same length.
+func parsePatterns(lines []string) ([]pattern, error) {
+	currentLines := []string{}
 	patterns := []pattern{}
 
+	addPattern := func() error {
+		g, errGrid := grid.Runes(currentLines)
+		if errGrid != nil {
+			return fmt.Errorf("cannot parse pattern %d: %w", len(patterns)+1, errGrid)
+		}
+
+		patterns = append(patterns, pattern{g})
+		currentLines = []string{}
+		return nil
+	}
+
 	for _, line := range lines {
 		if line == "" {
-			patterns = append(patterns, currentPattern)
-			currentPattern = pattern{}
+			if errAdd := addPattern(); errAdd != nil {
+				return nil, errAdd
+			}
 		} else {
-			currentPattern = append(currentPattern, []rune(line))
+			currentLines = append(currentLines, line)
 		}
 	}
 
-	if len(currentPattern) != 0 {
-		patterns = append(patterns, currentPattern)
-	}
-
-	return patterns
-}
-
-// readFileLines reads a file and returns its contents as an array of strings.
-func readFileLines(filePath string) ([]string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
+	if len(currentLines) != 0 {
+		if errAdd := addPattern(); errAdd != nil {
+			return nil, errAdd
+		}
 	}
-	defer file.Close()
 
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	return lines, scanner.Err()
+	return patterns, nil
 }
 
 func main() {
-	lines, errRead := readFileLines(inputFile)
+	lines, errRead := input.ReadLines(inputFile)
 	if errRead != nil {
 		log.Fatal(errRead)
 	}
 
-	patterns := parsePatterns(lines)
+	patterns, errParse := parsePatterns(lines)
+	if errParse != nil {
+		log.Fatal(errParse)
+	}
 
 	sum := 0
 	for _, p := range patterns {
@@ -191,5 +186,5 @@ func main() {
 		sum += r.value()
 	}
 
-	fmt.Println(sum)
+	solution.Print(sum)
 }
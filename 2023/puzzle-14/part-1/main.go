@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/repl"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+	"github.com/maaxleq/advent-of-code-2023/lib/trace"
+)
+
+const inputFile = "input.txt"
+
+// platform represents a 2D grid of runes where 'O' represents a rounded rock, '#' represents a cube-shaped rock and '.' represents an empty space.
+type platform struct {
+	grid.Grid[rune]
+}
+
+// getLoad calculates the total load on the platform. It adds up the vertical positions of all 'O's, with the top row being the highest value.
+func (p platform) getLoad() int {
+	height := p.Height()
+	load := 0
+
+	for y := 0; y < p.Height(); y++ {
+		for _, r := range p.Row(y) {
+			if r == 'O' {
+				load += height - y
+			}
+		}
+	}
+
+	return load
+}
+
+// getNorthboundPosition finds the position directly above a given coordinate (x, y) where the next 'O' can be placed. If no obstruction is found, it returns 0.
+func (p platform) getNorthboundPosition(x, y int) int {
+	for i := y - 1; i >= 0; i-- {
+		if p.At(x, i) != '.' {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// tiltNorth simulates tilting the platform northward, causing all 'O's to move up as far as possible without overlapping.
+func (p *platform) tiltNorth() {
+	for y := 0; y < p.Height(); y++ {
+		for x := 0; x < p.Width(); x++ {
+			if p.At(x, y) == 'O' {
+				nPos := p.getNorthboundPosition(x, y)
+				p.Set(x, y, '.')
+				p.Set(x, nPos, 'O')
+			}
+		}
+	}
+
+	if trace.Enabled() {
+		trace.Emit("tilt", "direction", "north", "load", p.getLoad())
+	}
+}
+
+// parsePlatform converts a slice of strings into a platform. Each string represents a row in the platform.
+// It returns an error if the rows are not all the same length.
+func parsePlatform(lines []string) (platform, error) {
+	g, errGrid := grid.Runes(lines)
+	if errGrid != nil {
+		return platform{}, fmt.Errorf("cannot parse platform: %w", errGrid)
+	}
+
+	return platform{g}, nil
+}
+
+func main() {
+	lines, errRead := input.ReadLines(inputFile)
+	if errRead != nil {
+		log.Fatal(errRead)
+	}
+
+	p, errParse := parsePlatform(lines)
+	if errParse != nil {
+		log.Fatal(errParse)
+	}
+
+	if repl.Enabled() {
+		if errRepl := repl.Run(os.Stdin, os.Stdout, "day 14 part 1", replCommands(p)); errRepl != nil {
+			log.Fatal(errRepl)
+		}
+		return
+	}
+
+	p.tiltNorth()
+
+	load := p.getLoad()
+
+	solution.Print(load)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/repl"
+)
+
+// replCommands returns the commands of the REPL, operating on a copy of the platform of the input.
+func replCommands(initial platform) []repl.Command {
+	p := platform{initial.Clone()}
+
+	return []repl.Command{
+		{
+			Name: "show",
+			Help: "draw the platform",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 0); errArgs != nil {
+					return "", errArgs
+				}
+
+				return p.String(), nil
+			},
+		},
+		{
+			Name: "load",
+			Help: "compute the load on the north support beams",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 0); errArgs != nil {
+					return "", errArgs
+				}
+
+				return strconv.Itoa(p.getLoad()), nil
+			},
+		},
+		{
+			Name: "tilt",
+			Help: "tilt the platform north",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 0); errArgs != nil {
+					return "", errArgs
+				}
+
+				p.tiltNorth()
+				return p.String(), nil
+			},
+		},
+		{
+			Name: "reset",
+			Help: "restore the platform of the input",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 0); errArgs != nil {
+					return "", errArgs
+				}
+
+				p = platform{initial.Clone()}
+				return p.String(), nil
+			},
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/term"
+)
+
+// styleTile draws a tile of the platform in the terminal.
+func styleTile(x, y int, r rune) term.Cell {
+	switch r {
+	case 'O':
+		return term.Cell{Rune: 'O', Color: term.BrightYellow}
+	case '#':
+		return term.Cell{Rune: '#', Color: term.Gray}
+	default:
+		return term.Cell{Rune: '.', Color: term.Blue}
+	}
+}
+
+// animateSpinCycles animates the given number of spin cycles from the platform on the screen, one frame per tilt.
+// It leaves the platform untouched.
+func (p platform) animateSpinCycles(screen *term.Screen, cycles int) {
+	term.Draw(screen, p.Grid, styleTile, fmt.Sprintf("cycle 0, load %d", p.getLoad()))
+
+	current := platform{p.Clone()}
+	for i := 1; i <= cycles; i++ {
+		tilts := []struct {
+			direction string
+			tilt      func()
+		}{
+			{"north", current.tiltNorth},
+			{"west", current.tiltWest},
+			{"south", current.tiltSouth},
+			{"east", current.tiltEast},
+		}
+
+		for _, t := range tilts {
+			t.tilt()
+			term.Draw(screen, current.Grid, styleTile, fmt.Sprintf("cycle %d, tilted %s, load %d", i, t.direction, current.getLoad()))
+		}
+	}
+}
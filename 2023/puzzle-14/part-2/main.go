@@ -0,0 +1,260 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/cycle"
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/render"
+	"github.com/maaxleq/advent-of-code-2023/lib/repl"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+	"github.com/maaxleq/advent-of-code-2023/lib/term"
+	"github.com/maaxleq/advent-of-code-2023/lib/trace"
+)
+
+// inputFile defines the name of the file to be read.
+const inputFile = "input.txt"
+
+// spinCycles is the number of spin cycles after which the load must be computed.
+const spinCycles = 1_000_000_000
+
+// platform represents a 2D grid of runes where 'O' represents a rounded rock, '#' represents a cube-shaped rock and '.' represents an empty space.
+type platform struct {
+	grid.Grid[rune]
+}
+
+// getLoad calculates the total load on the platform. It adds up the vertical positions of all 'O's, with the top row being the highest value.
+func (p platform) getLoad() int {
+	height := p.Height()
+	load := 0
+
+	for y := 0; y < p.Height(); y++ {
+		for _, r := range p.Row(y) {
+			if r == 'O' {
+				load += height - y
+			}
+		}
+	}
+
+	return load
+}
+
+// hash generates a compact key of the platform's current state. Cube-shaped rocks never move,
+// so the state is the positions of the rounded rocks, packed as varints of their index in reading order.
+func (p platform) hash() string {
+	key := []byte{}
+	for y := 0; y < p.Height(); y++ {
+		for x, r := range p.Row(y) {
+			if r == 'O' {
+				key = binary.AppendUvarint(key, uint64(y*p.Width()+x))
+			}
+		}
+	}
+
+	return string(key)
+}
+
+// getNorthboundPosition finds the next position to the north where an 'O' can move.
+func (p platform) getNorthboundPosition(x, y int) int {
+	// Check each position north of the current one.
+	for i := y - 1; i >= 0; i-- {
+		if p.At(x, i) != '.' {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// getSouthboundPosition finds the next position to the south where an 'O' can move.
+func (p platform) getSouthboundPosition(x, y int) int {
+	// Check each position south of the current one.
+	for i := y + 1; i < p.Height(); i++ {
+		if p.At(x, i) != '.' {
+			return i - 1
+		}
+	}
+
+	return p.Height() - 1
+}
+
+// getWestboundPosition finds the next position to the west where an 'O' can move.
+func (p platform) getWestboundPosition(x, y int) int {
+	// Check each position west of the current one.
+	for i := x - 1; i >= 0; i-- {
+		if p.At(i, y) != '.' {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// getEastboundPosition finds the next position to the east where an 'O' can move.
+func (p platform) getEastboundPosition(x, y int) int {
+	// Check each position east of the current one.
+	for i := x + 1; i < p.Width(); i++ {
+		if p.At(i, y) != '.' {
+			return i - 1
+		}
+	}
+
+	return p.Width() - 1
+}
+
+// tiltNorth tilts the platform north, moving all 'O's upwards.
+func (p *platform) tiltNorth() {
+	// Move each 'O' to its northbound position.
+	for y := 0; y < p.Height(); y++ {
+		for x := 0; x < p.Width(); x++ {
+			if p.At(x, y) == 'O' {
+				nPos := p.getNorthboundPosition(x, y)
+				p.Set(x, y, '.')
+				p.Set(x, nPos, 'O')
+			}
+		}
+	}
+
+	if trace.Enabled() {
+		trace.Emit("tilt", "direction", "north", "load", p.getLoad())
+	}
+}
+
+// tiltSouth tilts the platform south, moving all 'O's downwards.
+func (p *platform) tiltSouth() {
+	// Move each 'O' to its southbound position.
+	for y := p.Height() - 1; y >= 0; y-- {
+		for x := 0; x < p.Width(); x++ {
+			if p.At(x, y) == 'O' {
+				sPos := p.getSouthboundPosition(x, y)
+				p.Set(x, y, '.')
+				p.Set(x, sPos, 'O')
+			}
+		}
+	}
+
+	if trace.Enabled() {
+		trace.Emit("tilt", "direction", "south", "load", p.getLoad())
+	}
+}
+
+// tiltWest tilts the platform west, moving all 'O's to the left.
+func (p *platform) tiltWest() {
+	// Move each 'O' to its westbound position.
+	for x := 0; x < p.Width(); x++ {
+		for y := 0; y < p.Height(); y++ {
+			if p.At(x, y) == 'O' {
+				wPos := p.getWestboundPosition(x, y)
+				p.Set(x, y, '.')
+				p.Set(wPos, y, 'O')
+			}
+		}
+	}
+
+	if trace.Enabled() {
+		trace.Emit("tilt", "direction", "west", "load", p.getLoad())
+	}
+}
+
+// tiltEast tilts the platform east, moving all 'O's to the right.
+func (p *platform) tiltEast() {
+	// Move each 'O' to its eastbound position.
+	for x := p.Width() - 1; x >= 0; x-- {
+		for y := 0; y < p.Height(); y++ {
+			if p.At(x, y) == 'O' {
+				ePos := p.getEastboundPosition(x, y)
+				p.Set(x, y, '.')
+				p.Set(ePos, y, 'O')
+			}
+		}
+	}
+
+	if trace.Enabled() {
+		trace.Emit("tilt", "direction", "east", "load", p.getLoad())
+	}
+}
+
+// rotate performs a full rotation of the platform, tilting it in all four cardinal directions.
+func (p *platform) rotate() {
+	p.tiltNorth()
+	p.tiltWest()
+	p.tiltSouth()
+	p.tiltEast()
+}
+
+// spin returns the platform after one spin cycle, leaving p untouched.
+func (p platform) spin() platform {
+	next := platform{p.Clone()}
+	next.rotate()
+
+	return next
+}
+
+// findSpinCycle finds after how many spin cycles the platform starts repeating, searching at most limit cycles.
+func (p platform) findSpinCycle(limit int) (cycle.Result[platform], error) {
+	res, errCycle := cycle.Hashed(p, platform.spin, platform.hash, limit)
+	if errCycle != nil {
+		return res, fmt.Errorf("cannot find spin cycle: %w", errCycle)
+	}
+
+	if trace.Enabled() {
+		trace.Emit("cycle", "start", res.Start, "end", res.Start+res.Period)
+	}
+	return res, nil
+}
+
+// parsePlatform converts an array of strings into a platform structure.
+// It returns an error if the rows are not all the same length.
+func parsePlatform(lines []string) (platform, error) {
+	g, errGrid := grid.Runes(lines)
+	if errGrid != nil {
+		return platform{}, fmt.Errorf("cannot parse platform: %w", errGrid)
+	}
+
+	return platform{g}, nil
+}
+
+func main() {
+	lines, errRead := input.ReadLines(inputFile)
+	if errRead != nil {
+		log.Fatal(errRead)
+	}
+
+	p, errParse := parsePlatform(lines)
+	if errParse != nil {
+		log.Fatal(errParse)
+	}
+
+	if repl.Enabled() {
+		if errRepl := repl.Run(os.Stdin, os.Stdout, "day 14 part 2", replCommands(p)); errRepl != nil {
+			log.Fatal(errRepl)
+		}
+		return
+	}
+
+	// Detect the cycle of the platform, which gives its state after any number of spin cycles.
+	res, errCycle := p.findSpinCycle(spinCycles)
+	if errCycle != nil {
+		log.Fatal(errCycle)
+	}
+
+	if render.Enabled() {
+		if errRender := p.renderSpinCycles(res.Start + res.Period); errRender != nil {
+			log.Fatal(errRender)
+		}
+	}
+
+	if screen := term.Start(); screen != nil {
+		p.animateSpinCycles(screen, res.Start+res.Period)
+		screen.Stop()
+	}
+
+	// Calculate and print the final load on the platform.
+	load := res.StateAt(spinCycles).getLoad()
+
+	solution.Print(load)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"time"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/render"
+)
+
+// renderScale is the size in pixels of a tile in rendered images.
+const renderScale = 3
+
+// tiltDelay is how long each tilt is shown in rendered animations.
+const tiltDelay = 50 * time.Millisecond
+
+// Colors of the rendered platform, as indices in platformPalette.
+const (
+	colorEmpty uint8 = iota
+	colorRounded
+	colorCube
+)
+
+// platformPalette holds the colors of the rendered platform.
+var platformPalette = color.Palette{
+	colorEmpty:   color.RGBA{R: 0x0f, G: 0x0f, B: 0x23, A: 0xff},
+	colorRounded: color.RGBA{R: 0xff, G: 0xff, B: 0x66, A: 0xff},
+	colorCube:    color.RGBA{R: 0x77, G: 0x77, B: 0x88, A: 0xff},
+}
+
+// frame draws the platform.
+func (p platform) frame() *image.Paletted {
+	return render.Frame(p.Grid, renderScale, platformPalette, func(x, y int, r rune) uint8 {
+		switch r {
+		case 'O':
+			return colorRounded
+		case '#':
+			return colorCube
+		default:
+			return colorEmpty
+		}
+	})
+}
+
+// renderSpinCycles animates the given number of spin cycles from the platform, one frame per tilt.
+// It leaves the platform untouched.
+func (p platform) renderSpinCycles(cycles int) error {
+	anim := &render.Animation{}
+	anim.Add(p.frame(), tiltDelay)
+
+	current := platform{p.Clone()}
+	for i := 0; i < cycles; i++ {
+		for _, tilt := range []func(){current.tiltNorth, current.tiltWest, current.tiltSouth, current.tiltEast} {
+			tilt()
+			anim.Add(current.frame(), tiltDelay)
+		}
+	}
+
+	return render.WriteGIF("day-14-spin-cycles", anim)
+}
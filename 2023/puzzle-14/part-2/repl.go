@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/repl"
+)
+
+// parseCount parses a number of cycles typed in the REPL.
+func parseCount(s string) (int, error) {
+	n, errConv := strconv.Atoi(s)
+	if errConv != nil || n < 0 {
+		return 0, fmt.Errorf("invalid number of cycles: %s", s)
+	}
+
+	return n, nil
+}
+
+// replCommands returns the commands of the REPL, operating on a copy of the platform of the input.
+func replCommands(initial platform) []repl.Command {
+	p := platform{initial.Clone()}
+
+	return []repl.Command{
+		{
+			Name: "show",
+			Help: "draw the platform",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 0); errArgs != nil {
+					return "", errArgs
+				}
+
+				return p.String(), nil
+			},
+		},
+		{
+			Name: "load",
+			Help: "compute the load on the north support beams",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 0); errArgs != nil {
+					return "", errArgs
+				}
+
+				return strconv.Itoa(p.getLoad()), nil
+			},
+		},
+		{
+			Name: "tilt",
+			Args: "<north|west|south|east>",
+			Help: "tilt the platform in one direction",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 1); errArgs != nil {
+					return "", errArgs
+				}
+
+				switch args[0] {
+				case "north":
+					p.tiltNorth()
+				case "west":
+					p.tiltWest()
+				case "south":
+					p.tiltSouth()
+				case "east":
+					p.tiltEast()
+				default:
+					return "", fmt.Errorf("invalid direction: %s", args[0])
+				}
+
+				return p.String(), nil
+			},
+		},
+		{
+			Name: "cycle",
+			Args: "[n]",
+			Help: "run n spin cycles, 1 by default, and show the load",
+			Run: func(args []string) (string, error) {
+				if len(args) > 1 {
+					return "", fmt.Errorf("wrong number of arguments: expected at most 1, got %d", len(args))
+				}
+
+				n := 1
+				if len(args) > 0 {
+					var errCount error
+					if n, errCount = parseCount(args[0]); errCount != nil {
+						return "", errCount
+					}
+				}
+
+				for i := 0; i < n; i++ {
+					p.rotate()
+				}
+
+				return strconv.Itoa(p.getLoad()), nil
+			},
+		},
+		{
+			Name: "loadafter",
+			Args: "<n>",
+			Help: "compute the load after n spin cycles from the input platform, skipping repeated cycles",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 1); errArgs != nil {
+					return "", errArgs
+				}
+
+				n, errCount := parseCount(args[0])
+				if errCount != nil {
+					return "", errCount
+				}
+
+				res, errCycle := initial.findSpinCycle(n)
+				if errCycle == nil {
+					return strconv.Itoa(res.StateAt(n).getLoad()), nil
+				}
+
+				// No state repeats within n spin cycles, all of them have to be run.
+				after := platform{initial.Clone()}
+				for i := 0; i < n; i++ {
+					after.rotate()
+				}
+
+				return strconv.Itoa(after.getLoad()), nil
+			},
+		},
+		{
+			Name: "reset",
+			Help: "restore the platform of the input",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 0); errArgs != nil {
+					return "", errArgs
+				}
+
+				p = platform{initial.Clone()}
+				return p.String(), nil
+			},
+		},
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/geom"
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+	"github.com/maaxleq/advent-of-code-2023/lib/term"
+)
+
+// animateSplit draws on the screen the contraption with the tiles energized so far, while the beam splits at pos.
+func (g contraption) animateSplit(screen *term.Screen, eGrid grid.Grid[bool], pos geom.Point) {
+	energized := eGrid.Count(func(energized bool) bool {
+		return energized
+	})
+
+	term.Draw(screen, g.Grid, func(x, y int, r rune) term.Cell {
+		switch {
+		case x == pos.X && y == pos.Y:
+			return term.Cell{Rune: r, Color: term.BrightRed}
+		case r != '.' && eGrid.At(x, y):
+			return term.Cell{Rune: r, Color: term.BrightYellow}
+		case r != '.':
+			return term.Cell{Rune: r, Color: term.Gray}
+		case eGrid.At(x, y):
+			return term.Cell{Rune: '#', Color: term.Yellow}
+		default:
+			return term.Cell{Rune: '.', Color: term.Blue}
+		}
+	}, fmt.Sprintf("beam split at %s, %d tiles energized", pos, energized))
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/geom"
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/render"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+	"github.com/maaxleq/advent-of-code-2023/lib/term"
+	"github.com/maaxleq/advent-of-code-2023/lib/trace"
+)
+
+const inputFile = "input.txt"
+
+// beam represents a ray with a starting position and a direction.
+type beam struct {
+	pos geom.Point
+	dir geom.Direction
+}
+
+// memBeam is a map that tracks if a beam has been visited in the simulation.
+type memBeam map[beam]bool
+
+// contraption represents a 2D grid of runes, where each rune corresponds to a tile type.
+type contraption struct {
+	grid.Grid[rune]
+}
+
+// simulate projects a beam through the grid, altering its path based on tile types,
+// and records the energized tiles in eGrid. It uses memBeam to avoid revisiting the same path.
+// Splits are animated on the screen, unless it is nil.
+func (g contraption) simulate(screen *term.Screen, eGrid grid.Grid[bool], mem memBeam, start geom.Point, dir geom.Direction) {
+	b := beam{
+		pos: start,
+		dir: dir,
+	}
+
+	if visited, found := mem[b]; found && visited {
+		return
+	}
+
+	mem[b] = true
+
+	pos := start
+	cont := true
+
+	for cont {
+		if !g.InBounds(pos.X, pos.Y) {
+			break
+		}
+
+		eGrid.Set(pos.X, pos.Y, true)
+
+		switch g.At(pos.X, pos.Y) {
+		case '.':
+			pos = pos.Move(dir)
+		case '\\':
+			dir = dir.ReflectBackslash()
+			pos = pos.Move(dir)
+		case '/':
+			dir = dir.ReflectSlash()
+			pos = pos.Move(dir)
+		case '-':
+			if dir.Vertical() {
+				trace.Emit("split", "x", pos.X, "y", pos.Y, "tile", "-", "into", "west east")
+				if screen != nil {
+					g.animateSplit(screen, eGrid, pos)
+				}
+				g.simulate(screen, eGrid, mem, pos.Move(geom.West), geom.West)
+				g.simulate(screen, eGrid, mem, pos.Move(geom.East), geom.East)
+				cont = false
+			} else {
+				pos = pos.Move(dir)
+			}
+		case '|':
+			if dir.Horizontal() {
+				trace.Emit("split", "x", pos.X, "y", pos.Y, "tile", "|", "into", "north south")
+				if screen != nil {
+					g.animateSplit(screen, eGrid, pos)
+				}
+				g.simulate(screen, eGrid, mem, pos.Move(geom.North), geom.North)
+				g.simulate(screen, eGrid, mem, pos.Move(geom.South), geom.South)
+				cont = false
+			} else {
+				pos = pos.Move(dir)
+			}
+		}
+	}
+}
+
+// energize runs the simulation for the entire grid, animating it on the screen unless it is nil,
+// and returns which tiles are energized.
+func (g contraption) energize(screen *term.Screen) grid.Grid[bool] {
+	eGrid := grid.New[bool](g.Width(), g.Height())
+
+	mem := make(memBeam)
+	g.simulate(screen, eGrid, mem, geom.Point{X: 0, Y: 0}, geom.East)
+
+	return eGrid
+}
+
+// countEnergizedTiles counts the number of energized tiles of the simulation.
+func countEnergizedTiles(eGrid grid.Grid[bool]) int {
+	return eGrid.Count(func(energized bool) bool {
+		return energized
+	})
+}
+
+// parseContraption converts an array of strings into a contraption.
+// It returns an error if the rows are not all the same length.
+func parseContraption(lines []string) (contraption, error) {
+	g, errGrid := grid.Runes(lines)
+	if errGrid != nil {
+		return contraption{}, fmt.Errorf("cannot parse contraption: %w", errGrid)
+	}
+
+	return contraption{g}, nil
+}
+
+func main() {
+	lines, errRead := input.ReadLines(inputFile)
+	if errRead != nil {
+		log.Fatal(errRead)
+	}
+
+	g, errParse := parseContraption(lines)
+	if errParse != nil {
+		log.Fatal(errParse)
+	}
+
+	screen := term.Start()
+	eGrid := g.energize(screen)
+	screen.Stop()
+
+	if render.Enabled() {
+		if errRender := g.renderEnergized(eGrid); errRender != nil {
+			log.Fatal(errRender)
+		}
+	}
+
+	solution.Print(countEnergizedTiles(eGrid))
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+	"github.com/maaxleq/advent-of-code-2023/lib/render"
+)
+
+// renderScale is the size in pixels of a tile in rendered images.
+const renderScale = 4
+
+// Colors of the rendered contraption, as indices in contraptionPalette.
+const (
+	colorEmpty uint8 = iota
+	colorEnergized
+	colorDevice
+	colorEnergizedDevice
+)
+
+// contraptionPalette holds the colors of the rendered contraption.
+var contraptionPalette = color.Palette{
+	colorEmpty:           color.RGBA{R: 0x0f, G: 0x0f, B: 0x23, A: 0xff},
+	colorEnergized:       color.RGBA{R: 0xff, G: 0x99, B: 0x00, A: 0xff},
+	colorDevice:          color.RGBA{R: 0x77, G: 0x77, B: 0x88, A: 0xff},
+	colorEnergizedDevice: color.RGBA{R: 0xff, G: 0xff, B: 0x66, A: 0xff},
+}
+
+// renderEnergized draws the contraption with its mirrors and splitters, and the tiles the beam energizes.
+func (g contraption) renderEnergized(eGrid grid.Grid[bool]) error {
+	img := render.Frame(g.Grid, renderScale, contraptionPalette, func(x, y int, r rune) uint8 {
+		switch {
+		case r != '.' && eGrid.At(x, y):
+			return colorEnergizedDevice
+		case r != '.':
+			return colorDevice
+		case eGrid.At(x, y):
+			return colorEnergized
+		default:
+			return colorEmpty
+		}
+	})
+
+	return render.WritePNG("day-16-energized", img)
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/geom"
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+	"github.com/maaxleq/advent-of-code-2023/lib/trace"
+)
+
+const inputFile = "input.txt"
+
+// beam represents a ray with a starting position and a direction.
+type beam struct {
+	pos geom.Point
+	dir geom.Direction
+}
+
+// memBeam is a map that tracks if a beam has been visited in the simulation.
+type memBeam map[beam]bool
+
+// contraption represents a 2D grid of runes, where each rune corresponds to a tile type.
+type contraption struct {
+	grid.Grid[rune]
+}
+
+// simulate projects a beam through the grid, altering its path based on tile types,
+// and records the energized tiles in eGrid. It uses memBeam to avoid revisiting the same path.
+func (g contraption) simulate(eGrid grid.Grid[bool], mem memBeam, start geom.Point, dir geom.Direction) {
+	b := beam{
+		pos: start,
+		dir: dir,
+	}
+
+	if visited, found := mem[b]; found && visited {
+		return
+	}
+
+	mem[b] = true
+
+	pos := start
+	cont := true
+
+	for cont {
+		if !g.InBounds(pos.X, pos.Y) {
+			break
+		}
+
+		eGrid.Set(pos.X, pos.Y, true)
+
+		switch g.At(pos.X, pos.Y) {
+		case '.':
+			pos = pos.Move(dir)
+		case '\\':
+			dir = dir.ReflectBackslash()
+			pos = pos.Move(dir)
+		case '/':
+			dir = dir.ReflectSlash()
+			pos = pos.Move(dir)
+		case '-':
+			if dir.Vertical() {
+				trace.Emit("split", "x", pos.X, "y", pos.Y, "tile", "-", "into", "west east")
+				g.simulate(eGrid, mem, pos.Move(geom.West), geom.West)
+				g.simulate(eGrid, mem, pos.Move(geom.East), geom.East)
+				cont = false
+			} else {
+				pos = pos.Move(dir)
+			}
+		case '|':
+			if dir.Horizontal() {
+				trace.Emit("split", "x", pos.X, "y", pos.Y, "tile", "|", "into", "north south")
+				g.simulate(eGrid, mem, pos.Move(geom.North), geom.North)
+				g.simulate(eGrid, mem, pos.Move(geom.South), geom.South)
+				cont = false
+			} else {
+				pos = pos.Move(dir)
+			}
+		}
+	}
+}
+
+// countEnergizedTiles calculates the number of energized tiles in the grid
+// by simulating the path of a beam from a given starting point and direction.
+func (g contraption) countEnergizedTiles(start geom.Point, dir geom.Direction) int {
+	eGrid := grid.New[bool](g.Width(), g.Height())
+
+	mem := make(memBeam)
+	g.simulate(eGrid, mem, start, dir)
+
+	return eGrid.Count(func(energized bool) bool {
+		return energized
+	})
+}
+
+// findMaxEnergizedTiles finds the maximum number of tiles that can be energized
+// by a beam emitted from any edge of the grid.
+func (g contraption) findMaxEnergizedTiles() int {
+	maxEnergizedTiles := 0
+
+	bs := []beam{}
+
+	maxX := g.Width() - 1
+	maxY := g.Height() - 1
+
+	for y := 0; y < g.Height(); y++ {
+		bs = append(bs, beam{
+			pos: geom.Point{X: 0, Y: y},
+			dir: geom.East,
+		}, beam{
+			pos: geom.Point{X: maxX, Y: y},
+			dir: geom.West,
+		})
+	}
+
+	for x := 0; x < g.Width(); x++ {
+		bs = append(bs, beam{
+			pos: geom.Point{X: x, Y: 0},
+			dir: geom.South,
+		}, beam{
+			pos: geom.Point{X: x, Y: maxY},
+			dir: geom.North,
+		})
+	}
+
+	ch := make(chan int, len(bs))
+	wg := sync.WaitGroup{}
+
+	for _, b := range bs {
+		wg.Add(1)
+		go func(b beam) {
+			defer wg.Done()
+			energizedTiles := g.countEnergizedTiles(b.pos, b.dir)
+			ch <- energizedTiles
+		}(b)
+	}
+	wg.Wait()
+	close(ch)
+
+	for count := range ch {
+		if count > maxEnergizedTiles {
+			maxEnergizedTiles = count
+		}
+	}
+
+	return maxEnergizedTiles
+}
+
+func parseContraption(lines []string) (contraption, error) {
+	g, errGrid := grid.Runes(lines)
+	if errGrid != nil {
+		return contraption{}, fmt.Errorf("cannot parse contraption: %w", errGrid)
+	}
+
+	return contraption{g}, nil
+}
+
+func main() {
+	lines, errRead := input.ReadLines(inputFile)
+	if errRead != nil {
+		log.Fatal(errRead)
+	}
+
+	g, errParse := parseContraption(lines)
+	if errParse != nil {
+		log.Fatal(errParse)
+	}
+
+	energizedTiles := g.findMaxEnergizedTiles()
+
+	solution.Print(energizedTiles)
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// String writes the bag in the syntax of parseBag, colors sorted.
+func (b bag) String() string {
+	parts := []string{}
+	for _, c := range b.colors() {
+		parts = append(parts, fmt.Sprintf("%d %s", b[c], c))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// total returns the number of cubes in the bag.
+func (b bag) total() int {
+	total := 0
+	for _, count := range b {
+		total += count
+	}
+
+	return total
+}
+
+// minimalBag returns the bag with the fewest cubes of each color making every game possible.
+// As colors are independent, it is also the bag with the fewest cubes in total doing so.
+func minimalBag(games []*game) bag {
+	minimal := make(bag)
+	for _, game := range games {
+		for _, set := range game.sets {
+			for c, count := range set.countColors() {
+				minimal[c] = max(minimal[c], count)
+			}
+		}
+	}
+
+	return minimal
+}
+
+// parseIDs parses a comma separated list of game IDs.
+func parseIDs(s string) (map[int]bool, error) {
+	ids := make(map[int]bool)
+	for _, rawID := range strings.Split(s, ",") {
+		id, errID := strconv.Atoi(strings.TrimSpace(rawID))
+		if errID != nil {
+			return nil, fmt.Errorf("invalid game id %q", strings.TrimSpace(rawID))
+		}
+		ids[id] = true
+	}
+
+	return ids, nil
+}
+
+// parseCandidates parses bags separated by semicolons, each in the syntax of parseBag.
+func parseCandidates(s string) ([]bag, error) {
+	candidates := []bag{}
+	for _, rawBag := range strings.Split(s, ";") {
+		b, errBag := parseBag(rawBag)
+		if errBag != nil {
+			return nil, fmt.Errorf("invalid candidate bag %q: %w", strings.TrimSpace(rawBag), errBag)
+		}
+		candidates = append(candidates, b)
+	}
+
+	return candidates, nil
+}
+
+// logChoose returns the logarithm of the binomial coefficient n choose k, -Inf if k is greater than n.
+func logChoose(n, k int) float64 {
+	if k < 0 || k > n {
+		return math.Inf(-1)
+	}
+
+	lgn, _ := math.Lgamma(float64(n + 1))
+	lgk, _ := math.Lgamma(float64(k + 1))
+	lgnk, _ := math.Lgamma(float64(n - k + 1))
+
+	return lgn - lgk - lgnk
+}
+
+// logLikelihood returns the logarithm of the probability of drawing the sets of the games from the bag,
+// each set being a handful of cubes drawn at random and put back before the next set.
+// Within a handful, cubes are drawn with replacement (multinomial law) or without (multivariate hypergeometric law).
+// It is -Inf if a set cannot be drawn from the bag.
+func logLikelihood(games []*game, b bag, withReplacement bool) float64 {
+	total := b.total()
+
+	logL := 0.0
+	for _, game := range games {
+		for _, set := range game.sets {
+			counts := set.countColors()
+
+			drawn := 0
+			for c, count := range counts {
+				if count > b[c] {
+					// More cubes of a color than the bag holds, which no handful can be.
+					return math.Inf(-1)
+				}
+				drawn += count
+			}
+
+			if withReplacement {
+				// drawn! / prod(count!) * prod(p^count)
+				lg, _ := math.Lgamma(float64(drawn + 1))
+				logL += lg
+				for c, count := range counts {
+					if count == 0 {
+						// p^0 is 1, even for a color missing from the bag.
+						continue
+					}
+					lgc, _ := math.Lgamma(float64(count + 1))
+					logL += float64(count)*math.Log(float64(b[c])/float64(total)) - lgc
+				}
+			} else {
+				// prod(C(bag, count)) / C(total, drawn)
+				logL -= logChoose(total, drawn)
+				for c, count := range counts {
+					if count == 0 {
+						continue
+					}
+					logL += logChoose(b[c], count)
+				}
+			}
+		}
+	}
+
+	return logL
+}
+
+// relativeLikelihoods turns log-likelihoods into probabilities summing to 1, as the posterior probabilities
+// of the candidates with a uniform prior. All of them are 0 if no candidate is possible.
+func relativeLikelihoods(logLs []float64) []float64 {
+	best := math.Inf(-1)
+	for _, logL := range logLs {
+		best = math.Max(best, logL)
+	}
+
+	probs := make([]float64, len(logLs))
+	if math.IsInf(best, -1) {
+		return probs
+	}
+
+	sum := 0.0
+	for i, logL := range logLs {
+		probs[i] = math.Exp(logL - best)
+		sum += probs[i]
+	}
+	for i := range probs {
+		probs[i] /= sum
+	}
+
+	return probs
+}
+
+// writeInference writes what the games tell about the bag: the minimal bag making them all possible,
+// the smallest bag making the target games possible, and how likely each candidate bag is to have produced the games.
+func writeInference(w io.Writer, games []*game, target map[int]bool, candidates []bag) {
+	minimal := minimalBag(games)
+	fmt.Fprintf(w, "minimal bag for all %d games: %s (%d cubes)\n", len(games), minimal, minimal.total())
+
+	targetGames, targetIDs := []*game{}, []string{}
+	for _, game := range games {
+		if target[game.id] {
+			targetGames = append(targetGames, game)
+			targetIDs = append(targetIDs, strconv.Itoa(game.id))
+		}
+	}
+	if len(targetGames) < len(target) {
+		fmt.Fprintf(w, "warning: %d target games do not exist\n", len(target)-len(targetGames))
+	}
+
+	smallest := minimalBag(targetGames)
+	fmt.Fprintf(w, "smallest bag making games %s possible: %s (%d cubes)\n", strings.Join(targetIDs, ", "), smallest, smallest.total())
+
+	// A larger bag only makes more games possible: if the smallest bag makes other games possible, every bag does.
+	others := []string{}
+	for _, game := range games {
+		if !target[game.id] && game.isPossible(smallest) {
+			others = append(others, strconv.Itoa(game.id))
+		}
+	}
+	if len(others) == 0 {
+		fmt.Fprintln(w, "it makes exactly these games possible")
+	} else {
+		fmt.Fprintf(w, "no bag makes exactly these games possible, it also makes games %s possible\n", strings.Join(others, ", "))
+	}
+
+	fmt.Fprintln(w, "likelihood of the games, each set being drawn at random from the candidate bag:")
+	withLogLs, withoutLogLs := make([]float64, len(candidates)), make([]float64, len(candidates))
+	for i, candidate := range candidates {
+		withLogLs[i] = logLikelihood(games, candidate, true)
+		withoutLogLs[i] = logLikelihood(games, candidate, false)
+	}
+	withProbs, withoutProbs := relativeLikelihoods(withLogLs), relativeLikelihoods(withoutLogLs)
+
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return withoutLogLs[order[i]] > withoutLogLs[order[j]]
+	})
+
+	fmt.Fprintf(w, "  %-32s %26s %26s\n", "bag", "with replacement", "without replacement")
+	for _, i := range order {
+		fmt.Fprintf(w, "  %-32s %14.2f (p = %.3f) %14.2f (p = %.3f)\n",
+			candidates[i], withLogLs[i], withProbs[i], withoutLogLs[i], withoutProbs[i])
+	}
+}
+
+// runInference writes the inference report to w, with the target games and candidate bags
+// of the flags, or their defaults for the given bag.
+func runInference(w io.Writer, games []*game, b bag, rawTarget, rawCandidates string) error {
+	target := make(map[int]bool)
+	if rawTarget == "" {
+		for _, game := range games {
+			if game.isPossible(b) {
+				target[game.id] = true
+			}
+		}
+	} else {
+		ids, errIDs := parseIDs(rawTarget)
+		if errIDs != nil {
+			return errIDs
+		}
+		target = ids
+	}
+
+	candidates := []bag{b, minimalBag(games)}
+	if rawCandidates != "" {
+		parsed, errCandidates := parseCandidates(rawCandidates)
+		if errCandidates != nil {
+			return errCandidates
+		}
+		candidates = parsed
+	}
+
+	writeInference(w, games, target, candidates)
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// closeTo tells whether got is want, up to rounding errors.
+func closeTo(got, want float64) bool {
+	return got == want || math.Abs(got-want) < 1e-9
+}
+
+func TestLogLikelihood(t *testing.T) {
+	b := bag{"red": 1, "blue": 1, "green": 0}
+
+	tests := []struct {
+		name string
+		set  set
+		want float64
+	}{
+		{"one cube", set{{1, "red"}}, math.Log(0.5)},
+		{"zero count of a color in the bag", set{{1, "red"}, {0, "blue"}}, math.Log(0.5)},
+		{"zero count of an empty color", set{{1, "red"}, {0, "green"}}, math.Log(0.5)},
+		{"zero count of a color missing from the bag", set{{0, "purple"}, {1, "red"}}, math.Log(0.5)},
+		{"only zero counts", set{{0, "purple"}, {0, "green"}}, 0},
+		{"more cubes than in the bag", set{{2, "red"}}, math.Inf(-1)},
+		{"cube of an empty color", set{{1, "green"}}, math.Inf(-1)},
+		{"cube of a color missing from the bag", set{{1, "purple"}}, math.Inf(-1)},
+	}
+
+	for _, test := range tests {
+		for _, withReplacement := range []bool{true, false} {
+			games := []*game{{id: 1, sets: []set{test.set}}}
+			got := logLikelihood(games, b, withReplacement)
+			if !closeTo(got, test.want) {
+				t.Errorf("%s, with replacement %t: got %v, want %v", test.name, withReplacement, got, test.want)
+			}
+		}
+	}
+}
+
+func TestLogLikelihoodDrawingMode(t *testing.T) {
+	b := bag{"red": 2, "blue": 2}
+	games := []*game{{id: 1, sets: []set{{{2, "red"}, {0, "blue"}}}}}
+
+	// With replacement, each cube is red with probability 1/2.
+	if got, want := logLikelihood(games, b, true), math.Log(0.25); !closeTo(got, want) {
+		t.Errorf("with replacement: got %v, want %v", got, want)
+	}
+	// Without, both red cubes are drawn out of the C(4, 2) = 6 handfuls.
+	if got, want := logLikelihood(games, b, false), math.Log(1.0/6); !closeTo(got, want) {
+		t.Errorf("without replacement: got %v, want %v", got, want)
+	}
+}
+
+func TestRelativeLikelihoods(t *testing.T) {
+	got := relativeLikelihoods([]float64{math.Log(1), math.Log(3), math.Inf(-1)})
+	want := []float64{0.25, 0.75, 0}
+	for i := range want {
+		if !closeTo(got[i], want[i]) {
+			t.Errorf("candidate %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	for i, prob := range relativeLikelihoods([]float64{math.Inf(-1), math.Inf(-1)}) {
+		if prob != 0 {
+			t.Errorf("impossible candidate %d: got %v, want 0", i, prob)
+		}
+	}
+}
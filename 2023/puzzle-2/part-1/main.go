@@ -0,0 +1,116 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+)
+
+const inputFile = "input.txt"
+
+type color string
+
+type cubes struct {
+	count int
+	color color
+}
+
+type set []cubes
+
+func (set *set) countColors() map[color]int {
+	counts := make(map[color]int)
+
+	for _, cubes := range []cubes(*set) {
+		counts[cubes.color] += cubes.count
+	}
+
+	return counts
+}
+
+type game struct {
+	id   int
+	sets []set
+}
+
+func (game *game) isPossible(b bag) bool {
+	for _, set := range game.sets {
+		for c, count := range set.countColors() {
+			if count > b[c] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func main() {
+	bagCubes := flag.String("bag", defaultBag, "cubes in the bag, like \""+defaultBag+"\"")
+	bagFile := flag.String("bag-file", "", "file holding the cubes in the bag, in the syntax of -bag, instead of the -bag flag")
+	infer := flag.Bool("infer", false, "instead of solving the puzzle, report what the games tell about the bag")
+	target := flag.String("target", "", "comma separated IDs of the games the inferred bag must make possible, those possible with the bag by default")
+	candidates := flag.String("candidates", "", "bags to compute the likelihood of, separated by semicolons, the bag and the minimal one by default")
+	query := flag.String("query", "", "instead of solving the puzzle, list the games matching a query like \"max(red) > 10 and any(set.blue == 0)\"")
+	agg := flag.String("agg", "", "with -query, comma separated aggregates to compute over the matching games instead of listing them, like \"sum(id), count()\"")
+	strict := flag.Bool("strict", false, "reject game records which do not follow exactly the format of the puzzle")
+	flag.Parse()
+
+	b, errBag := parseBag(*bagCubes)
+	if *bagFile != "" {
+		// Silently preferring one of them would hide a mistake in the command line.
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "bag" {
+				log.Fatal("-bag-file cannot be combined with -bag, the bag file already lists the cubes")
+			}
+		})
+		b, errBag = readBagFile(*bagFile)
+	}
+	if errBag != nil {
+		log.Fatal(errBag)
+	}
+
+	lines, errRead := input.ReadLines(inputFile)
+	if errRead != nil {
+		log.Fatal(errRead)
+	}
+
+	games := []*game{}
+	for i, line := range lines {
+		game, errParse := parseGameLine(line, b, *strict)
+		if errParse != nil {
+			log.Fatalf("line %d: %v", i+1, errParse)
+		}
+
+		if errColors := b.declares(game); errColors != nil {
+			log.Fatal(errColors)
+		}
+
+		games = append(games, game)
+	}
+
+	if *query != "" || *agg != "" {
+		if errQuery := runQuery(os.Stdout, games, b, *query, *agg); errQuery != nil {
+			log.Fatal(errQuery)
+		}
+		return
+	}
+
+	if *infer {
+		if errInference := runInference(os.Stdout, games, b, *target, *candidates); errInference != nil {
+			log.Fatal(errInference)
+		}
+		return
+	}
+
+	idsSum := 0
+	for _, game := range games {
+		if game.isPossible(b) {
+			idsSum += game.id
+		}
+	}
+
+	solution.Print(idsSum)
+}
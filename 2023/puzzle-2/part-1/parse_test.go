@@ -0,0 +1,80 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGameLineErrors(t *testing.T) {
+	b := bag{"red": 12, "green": 13, "blue": 14}
+
+	// An empty error means the line parses.
+	tests := []struct {
+		line       string
+		lenientErr string
+		strictErr  string
+	}{
+		{"Game 1: 3 blue, 4 red; 1 red", "", ""},
+		// Tokens.
+		{"Game 1: 3 blue, 4 red!", `column 22: unexpected character '!'`, `column 22: unexpected character '!'`},
+		{"Game ١: 3 blue", `column 6: non-ASCII digit '١', numbers must be written with 0 to 9`, `column 6: non-ASCII digit '١', numbers must be written with 0 to 9`},
+		{"Game 1: ３ blue", `column 9: non-ASCII digit '３', numbers must be written with 0 to 9`, `column 9: non-ASCII digit '３', numbers must be written with 0 to 9`},
+		// Grammar.
+		{"Game 1 3 blue", `column 8: expected ":", got "3"`, `column 8: expected ":", got "3"`},
+		{"Game 1:", `column 8: expected a number, got the end of the line`, `column 8: expected a number, got the end of the line`},
+		{"Game 1: 3 blue 4 red", `column 16: expected ",", ";" or the end of the line, got "4"`, `column 16: expected ",", ";" or the end of the line, got "4"`},
+		{"Game 1: 3 blue,; 4 red", `column 16: expected a number, got ";"`, `column 16: expected a number, got ";"`},
+		{"Game 1: 3 4 red", `column 11: expected a word, got "4"`, `column 11: expected a word, got "4"`},
+		{"Game 99999999999999999999: 1 red", `column 6: number 99999999999999999999 is too large`, `column 6: number 99999999999999999999 is too large`},
+		// Layout, only checked in strict mode.
+		{"game 1: 3 blue", "", `column 1: expected "Game", got "game"`},
+		{" Game 1: 3 blue", "", `column 1: unexpected whitespace before a word`},
+		{"Game 1 : 3 blue", "", `column 7: unexpected whitespace before ":"`},
+		{"Game 1:3 blue", "", `column 8: expected a single space before a number`},
+		{"Game 1: 3  blue", "", `column 10: expected a single space before a word`},
+		{"Game 1:\t3 blue", "", `column 8: expected a single space before a number`},
+		{"Game 1: 3 blue ; 4 red", "", `column 15: unexpected whitespace before ";"`},
+		{"Game 1: 3 blue ", "", `column 15: unexpected whitespace at the end of the line`},
+	}
+
+	for _, test := range tests {
+		for _, strict := range []bool{false, true} {
+			want := test.lenientErr
+			if strict {
+				want = test.strictErr
+			}
+
+			_, errParse := parseGameLine(test.line, b, strict)
+			switch {
+			case errParse == nil && want != "":
+				t.Errorf("%q, strict %t: got no error, want %q", test.line, strict, want)
+			case errParse != nil && errParse.Error() != want:
+				t.Errorf("%q, strict %t: got error %q, want %q", test.line, strict, errParse, want)
+			}
+		}
+	}
+}
+
+func TestParseGameLineColors(t *testing.T) {
+	b := bag{"red": 12, "green": 13, "blue": 14}
+	line := "Game 7: 3 Blues, 4 red; 1 GREEN"
+
+	lenient, errLenient := parseGameLine(line, b, false)
+	if errLenient != nil {
+		t.Fatalf("lenient: unexpected error %v", errLenient)
+	}
+	want := &game{id: 7, sets: []set{{{3, "blue"}, {4, "red"}}, {{1, "green"}}}}
+	if !reflect.DeepEqual(lenient, want) {
+		t.Errorf("lenient: got %+v, want %+v", lenient, want)
+	}
+
+	// Strict mode keeps colors as written, for the bag to reject them.
+	strict, errStrict := parseGameLine(line, b, true)
+	if errStrict != nil {
+		t.Fatalf("strict: unexpected error %v", errStrict)
+	}
+	want = &game{id: 7, sets: []set{{{3, "Blues"}, {4, "red"}}, {{1, "GREEN"}}}}
+	if !reflect.DeepEqual(strict, want) {
+		t.Errorf("strict: got %+v, want %+v", strict, want)
+	}
+}
@@ -0,0 +1,556 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A query filters games with a predicate and computes aggregates over the games it keeps, like
+//
+//	max(red) > 10 and any(set.blue == 0)
+//
+// Expressions are evaluated in one of three nested scopes: the list of games, a game, and a set of a game.
+// Aggregating functions evaluate their argument in the next scope for each of its items: over games in the
+// scope of the list, over sets in the scope of a game. They are sum, min, max and avg of numbers, count of
+// the items matching a predicate or of all of them with count(), and any and all of predicates.
+//
+// A game has an id and a number of sets, also named game.id and game.sets. A set has a count for each
+// color of the bag, as in red or set.red, a total number of cubes and an index from 1, as set.total and
+// set.index. Numbers combine with + - * /, compare with == != < <= > >=, and predicates with and, or, not.
+
+// queryScope is where an expression is evaluated.
+type queryScope int
+
+const (
+	scopeGames queryScope = iota // The list of games, where aggregates are computed.
+	scopeGame                    // A game, where the filter is evaluated.
+	scopeSet                     // A set of a game.
+)
+
+// queryType is the type of the value of an expression.
+type queryType int
+
+const (
+	typeNumber queryType = iota
+	typeBool
+)
+
+// String names the type in error messages.
+func (t queryType) String() string {
+	if t == typeBool {
+		return "predicate"
+	}
+	return "number"
+}
+
+// queryEnv holds what an expression is evaluated on. Fields of the scopes inner to the one of the expression are unset.
+type queryEnv struct {
+	games    []*game
+	game     *game
+	set      set
+	setIndex int
+}
+
+// queryNode is a compiled expression. Predicates evaluate to 1 when true and 0 when false.
+type queryNode struct {
+	typ    queryType
+	eval   func(env queryEnv) float64
+	source string // Text of the expression in the query, set for the expressions of the list only.
+}
+
+// queryToken is a lexical token of a query, with its position in the query for error messages.
+type queryToken struct {
+	text string
+	pos  int
+	end  int // Column after the token.
+}
+
+// tokenizeQuery splits a query into numbers, identifiers (which may hold dots), operators and parentheses.
+func tokenizeQuery(query string) ([]queryToken, error) {
+	tokens := []queryToken{}
+
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case unicode.IsDigit(r):
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+		case unicode.IsLetter(r) || r == '_':
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+		case strings.ContainsRune("=!<>", r) && i+1 < len(runes) && runes[i+1] == '=':
+			i += 2
+		case strings.ContainsRune("<>+-*/(),", r):
+			i++
+		default:
+			return nil, fmt.Errorf("at column %d: unexpected character %q", start+1, r)
+		}
+
+		tokens = append(tokens, queryToken{text: string(runes[start:i]), pos: start + 1, end: i + 1})
+	}
+
+	return tokens, nil
+}
+
+// queryParser compiles queries by recursive descent, checking the types of expressions and the names
+// they use in their scope.
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+	end    int // Column after the end of the query, for errors at its end.
+	scope  queryScope
+	colors map[color]bool
+}
+
+// peek returns the text of the next token, or an empty string at the end of the query.
+func (p *queryParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+
+	return p.tokens[p.pos].text
+}
+
+// errorf returns an error located at the next token.
+func (p *queryParser) errorf(format string, args ...any) error {
+	column := p.end
+	if p.pos < len(p.tokens) {
+		column = p.tokens[p.pos].pos
+	}
+
+	return fmt.Errorf("at column %d: %s", column, fmt.Sprintf(format, args...))
+}
+
+// expect consumes the next token, which must be text.
+func (p *queryParser) expect(text string) error {
+	if p.peek() != text {
+		if p.peek() == "" {
+			return p.errorf("expected %q, got the end of the query", text)
+		}
+		return p.errorf("expected %q, got %q", text, p.peek())
+	}
+
+	p.pos++
+	return nil
+}
+
+// parseExpr parses an expression of the current scope: or := and ("or" and)*.
+func (p *queryParser) parseExpr() (queryNode, error) {
+	return p.parseBinary([]string{"or"}, p.parseAnd)
+}
+
+// parseAnd parses and := not ("and" not)*.
+func (p *queryParser) parseAnd() (queryNode, error) {
+	return p.parseBinary([]string{"and"}, p.parseNot)
+}
+
+// parseNot parses not := "not" not | comparison.
+func (p *queryParser) parseNot() (queryNode, error) {
+	if p.peek() != "not" {
+		return p.parseComparison()
+	}
+
+	p.pos++
+	operand, errOperand := p.parseNot()
+	if errOperand != nil {
+		return queryNode{}, errOperand
+	}
+	if operand.typ != typeBool {
+		return queryNode{}, p.errorf("not needs a predicate, got a number")
+	}
+
+	return queryNode{typ: typeBool, eval: func(env queryEnv) float64 {
+		return 1 - operand.eval(env)
+	}}, nil
+}
+
+// parseComparison parses comparison := sum (("==" | "!=" | "<" | "<=" | ">" | ">=") sum)?.
+func (p *queryParser) parseComparison() (queryNode, error) {
+	return p.parseBinary([]string{"==", "!=", "<", "<=", ">", ">="}, p.parseSum)
+}
+
+// parseSum parses sum := product (("+" | "-") product)*.
+func (p *queryParser) parseSum() (queryNode, error) {
+	return p.parseBinary([]string{"+", "-"}, p.parseProduct)
+}
+
+// parseProduct parses product := unary (("*" | "/") unary)*.
+func (p *queryParser) parseProduct() (queryNode, error) {
+	return p.parseBinary([]string{"*", "/"}, p.parseUnary)
+}
+
+// parseBinary parses operands separated by any of the operators, associating them from the left.
+// Comparisons take two numbers and give a predicate, "and" and "or" take predicates, other operators take numbers.
+func (p *queryParser) parseBinary(operators []string, parseOperand func() (queryNode, error)) (queryNode, error) {
+	left, errLeft := parseOperand()
+	if errLeft != nil {
+		return queryNode{}, errLeft
+	}
+
+	for {
+		op := p.peek()
+		if !contains(operators, op) {
+			return left, nil
+		}
+		opToken := p.tokens[p.pos]
+		p.pos++
+
+		right, errRight := parseOperand()
+		if errRight != nil {
+			return queryNode{}, errRight
+		}
+
+		operandType, resultType := typeNumber, typeNumber
+		switch op {
+		case "and", "or":
+			operandType, resultType = typeBool, typeBool
+		case "==", "!=", "<", "<=", ">", ">=":
+			resultType = typeBool
+		}
+		if left.typ != operandType || right.typ != operandType {
+			return queryNode{}, fmt.Errorf("at column %d: %s needs two %ss", opToken.pos, op, operandType)
+		}
+
+		left = queryNode{typ: resultType, eval: binaryEval(op, left.eval, right.eval)}
+
+		// Comparisons do not chain.
+		if resultType == typeBool && operandType == typeNumber {
+			return left, nil
+		}
+	}
+}
+
+// binaryEval returns the evaluation of a binary operator.
+func binaryEval(op string, left, right func(queryEnv) float64) func(queryEnv) float64 {
+	boolean := func(b bool) float64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	switch op {
+	case "or":
+		return func(env queryEnv) float64 { return boolean(left(env) != 0 || right(env) != 0) }
+	case "and":
+		return func(env queryEnv) float64 { return boolean(left(env) != 0 && right(env) != 0) }
+	case "==":
+		return func(env queryEnv) float64 { return boolean(left(env) == right(env)) }
+	case "!=":
+		return func(env queryEnv) float64 { return boolean(left(env) != right(env)) }
+	case "<":
+		return func(env queryEnv) float64 { return boolean(left(env) < right(env)) }
+	case "<=":
+		return func(env queryEnv) float64 { return boolean(left(env) <= right(env)) }
+	case ">":
+		return func(env queryEnv) float64 { return boolean(left(env) > right(env)) }
+	case ">=":
+		return func(env queryEnv) float64 { return boolean(left(env) >= right(env)) }
+	case "+":
+		return func(env queryEnv) float64 { return left(env) + right(env) }
+	case "-":
+		return func(env queryEnv) float64 { return left(env) - right(env) }
+	case "*":
+		return func(env queryEnv) float64 { return left(env) * right(env) }
+	default:
+		return func(env queryEnv) float64 { return left(env) / right(env) }
+	}
+}
+
+// parseUnary parses unary := "-" unary | primary.
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if p.peek() != "-" {
+		return p.parsePrimary()
+	}
+
+	p.pos++
+	operand, errOperand := p.parseUnary()
+	if errOperand != nil {
+		return queryNode{}, errOperand
+	}
+	if operand.typ != typeNumber {
+		return queryNode{}, p.errorf("- needs a number, got a predicate")
+	}
+
+	return queryNode{typ: typeNumber, eval: func(env queryEnv) float64 {
+		return -operand.eval(env)
+	}}, nil
+}
+
+// parsePrimary parses primary := number | "(" expr ")" | function "(" expr? ")" | name.
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	text := p.peek()
+	switch {
+	case text == "":
+		return queryNode{}, p.errorf("unexpected end of the query")
+	case text == "(":
+		p.pos++
+		inner, errInner := p.parseExpr()
+		if errInner != nil {
+			return queryNode{}, errInner
+		}
+		return inner, p.expect(")")
+	case unicode.IsDigit([]rune(text)[0]):
+		value, errValue := strconv.ParseFloat(text, 64)
+		if errValue != nil {
+			return queryNode{}, p.errorf("invalid number %q", text)
+		}
+		p.pos++
+		return queryNode{typ: typeNumber, eval: func(queryEnv) float64 { return value }}, nil
+	case p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].text == "(":
+		return p.parseCall()
+	default:
+		return p.parseName()
+	}
+}
+
+// parseCall parses an aggregating function, whose argument is evaluated in the next scope.
+func (p *queryParser) parseCall() (queryNode, error) {
+	name := p.peek()
+	if p.scope == scopeSet {
+		return queryNode{}, p.errorf("%s cannot be used on a set, which has nothing to aggregate", name)
+	}
+
+	argTypes := map[string]queryType{
+		"sum":   typeNumber,
+		"min":   typeNumber,
+		"max":   typeNumber,
+		"avg":   typeNumber,
+		"count": typeBool,
+		"any":   typeBool,
+		"all":   typeBool,
+	}
+	argType, exists := argTypes[name]
+	if !exists {
+		return queryNode{}, p.errorf("unknown function %s, expected one of sum, min, max, avg, count, any, all", name)
+	}
+	p.pos += 2
+
+	// count() counts every item.
+	arg := queryNode{typ: typeBool, eval: func(queryEnv) float64 { return 1 }}
+	if name != "count" || p.peek() != ")" {
+		outer := p.scope
+		p.scope++
+		parsed, errArg := p.parseExpr()
+		p.scope = outer
+		if errArg != nil {
+			return queryNode{}, errArg
+		}
+		if parsed.typ != argType {
+			return queryNode{}, p.errorf("%s needs a %s, got a %s", name, argType, parsed.typ)
+		}
+		arg = parsed
+	}
+	if errClose := p.expect(")"); errClose != nil {
+		return queryNode{}, errClose
+	}
+
+	scope := p.scope
+	items := func(env queryEnv) []queryEnv {
+		inner := []queryEnv{}
+		if scope == scopeGames {
+			for _, game := range env.games {
+				inner = append(inner, queryEnv{games: env.games, game: game})
+			}
+		} else {
+			for i, set := range env.game.sets {
+				inner = append(inner, queryEnv{games: env.games, game: env.game, set: set, setIndex: i + 1})
+			}
+		}
+		return inner
+	}
+	resultType := typeNumber
+	if name == "any" || name == "all" {
+		resultType = typeBool
+	}
+
+	return queryNode{typ: resultType, eval: func(env queryEnv) float64 {
+		return aggregate(name, arg.eval, items(env))
+	}}, nil
+}
+
+// aggregate computes a function over the values of its argument for each item. Aggregates of no item are 0,
+// except all which is true.
+func aggregate(name string, arg func(queryEnv) float64, items []queryEnv) float64 {
+	result := 0.0
+	switch name {
+	case "min":
+		result = math.Inf(1)
+	case "max":
+		result = math.Inf(-1)
+	case "all":
+		result = 1
+	}
+
+	for _, item := range items {
+		value := arg(item)
+		switch name {
+		case "sum", "avg", "count":
+			result += value
+		case "min":
+			result = math.Min(result, value)
+		case "max":
+			result = math.Max(result, value)
+		case "any":
+			result = math.Max(result, value)
+		case "all":
+			result = math.Min(result, value)
+		}
+	}
+
+	switch {
+	case len(items) == 0 && name != "all":
+		return 0
+	case name == "avg":
+		return result / float64(len(items))
+	default:
+		return result
+	}
+}
+
+// parseName parses the name of a property of the game or of the set in scope.
+func (p *queryParser) parseName() (queryNode, error) {
+	name := p.peek()
+	node := queryNode{typ: typeNumber}
+
+	switch {
+	case p.scope >= scopeGame && (name == "id" || name == "game.id"):
+		node.eval = func(env queryEnv) float64 { return float64(env.game.id) }
+	case p.scope >= scopeGame && (name == "sets" || name == "game.sets"):
+		node.eval = func(env queryEnv) float64 { return float64(len(env.game.sets)) }
+	case p.scope == scopeSet && (name == "total" || name == "set.total"):
+		node.eval = func(env queryEnv) float64 {
+			total := 0
+			for _, cubes := range env.set {
+				total += cubes.count
+			}
+			return float64(total)
+		}
+	case p.scope == scopeSet && name == "set.index":
+		node.eval = func(env queryEnv) float64 { return float64(env.setIndex) }
+	case p.colors[color(strings.TrimPrefix(name, "set."))]:
+		c := color(strings.TrimPrefix(name, "set."))
+		if p.scope != scopeSet {
+			return queryNode{}, p.errorf("color %s is counted in sets, use it in a function like max(%s) or any(%s > 0)", c, c, c)
+		}
+		node.eval = func(env queryEnv) float64 { return float64(env.set.countColors()[c]) }
+	case p.scope == scopeGames:
+		return queryNode{}, p.errorf("unknown name %s, use game properties in a function like sum(id)", name)
+	default:
+		return queryNode{}, p.errorf("unknown name %s", name)
+	}
+
+	p.pos++
+	return node, nil
+}
+
+// contains returns true if the text is one of the strings.
+func contains(strs []string, text string) bool {
+	for _, s := range strs {
+		if s == text {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compileQuery compiles a comma separated list of expressions in the given scope, whose colors are those of the bag.
+func compileQuery(query string, scope queryScope, b bag) ([]queryNode, error) {
+	tokens, errTokens := tokenizeQuery(query)
+	if errTokens != nil {
+		return nil, errTokens
+	}
+
+	colors := make(map[color]bool)
+	for c := range b {
+		colors[c] = true
+	}
+
+	p := &queryParser{
+		tokens: tokens,
+		end:    len([]rune(query)) + 1,
+		scope:  scope,
+		colors: colors,
+	}
+
+	runes := []rune(query)
+	nodes := []queryNode{}
+	for {
+		first := p.pos
+		node, errNode := p.parseExpr()
+		if errNode != nil {
+			return nil, errNode
+		}
+		node.source = string(runes[tokens[first].pos-1 : tokens[p.pos-1].end-1])
+		nodes = append(nodes, node)
+
+		if p.peek() != "," {
+			break
+		}
+		p.pos++
+	}
+	if p.peek() != "" {
+		return nil, p.errorf("unexpected %q", p.peek())
+	}
+
+	return nodes, nil
+}
+
+// formatNumber writes a number without decimals when it is an integer.
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// runQuery writes the IDs of the games matching the filter, or if aggregates are given, their values over
+// the matching games. An empty filter matches every game.
+func runQuery(w io.Writer, games []*game, b bag, filter, aggregates string) error {
+	matching := games
+	if strings.TrimSpace(filter) != "" {
+		nodes, errFilter := compileQuery(filter, scopeGame, b)
+		if errFilter != nil {
+			return fmt.Errorf("invalid query: %w", errFilter)
+		}
+		if len(nodes) != 1 || nodes[0].typ != typeBool {
+			return fmt.Errorf("invalid query: expected a single predicate")
+		}
+
+		matching = []*game{}
+		for _, game := range games {
+			if nodes[0].eval(queryEnv{games: games, game: game}) != 0 {
+				matching = append(matching, game)
+			}
+		}
+	}
+
+	if aggregates == "" {
+		for _, game := range matching {
+			fmt.Fprintf(w, "game %d\n", game.id)
+		}
+		fmt.Fprintf(w, "%d of %d games match\n", len(matching), len(games))
+		return nil
+	}
+
+	nodes, errAggregates := compileQuery(aggregates, scopeGames, b)
+	if errAggregates != nil {
+		return fmt.Errorf("invalid aggregates: %w", errAggregates)
+	}
+
+	for _, node := range nodes {
+		fmt.Fprintf(w, "%s = %s\n", node.source, formatNumber(node.eval(queryEnv{games: matching})))
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+// testGames parses the games of the example of the puzzle.
+func testGames(t *testing.T, b bag) []*game {
+	t.Helper()
+
+	lines := []string{
+		"Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green",
+		"Game 2: 1 blue, 2 green; 3 green, 4 blue, 1 red; 1 green, 1 blue",
+		"Game 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red",
+		"Game 4: 1 green, 3 red, 6 blue; 3 green, 6 red; 3 green, 15 blue, 14 red",
+		"Game 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green",
+	}
+
+	games := []*game{}
+	for _, line := range lines {
+		game, errParse := parseGameLine(line, b, true)
+		if errParse != nil {
+			t.Fatalf("parsing %q: %v", line, errParse)
+		}
+		games = append(games, game)
+	}
+
+	return games
+}
+
+func TestQueryPrecedence(t *testing.T) {
+	b, _ := parseBag(defaultBag)
+
+	tests := []struct {
+		query string
+		want  float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"8 / 4 / 2", 1},
+		{"-2 * 3 + 1", -5},
+		{"- -2", 2},
+		{"1 + 1 == 2", 1},
+		{"1 == 1 or 1 == 1 and 1 == 2", 1},
+		{"(1 == 1 or 1 == 1) and 1 == 2", 0},
+		{"not 1 == 2 and 1 == 1", 1},
+		{"not (1 == 1 and 1 == 2)", 1},
+		{"not not 1 == 1", 1},
+	}
+
+	for _, test := range tests {
+		nodes, errCompile := compileQuery(test.query, scopeGames, b)
+		if errCompile != nil {
+			t.Errorf("%q: unexpected error %v", test.query, errCompile)
+			continue
+		}
+		if got := nodes[0].eval(queryEnv{}); got != test.want {
+			t.Errorf("%q: got %v, want %v", test.query, got, test.want)
+		}
+	}
+}
+
+func TestQueryScopes(t *testing.T) {
+	b, _ := parseBag(defaultBag)
+	games := testGames(t, b)
+
+	tests := []struct {
+		query string
+		want  float64
+	}{
+		{"sum(id)", 15},
+		{"count()", 5},
+		{"count(sets == 3)", 4},
+		{"max(max(red))", 20},
+		{"max(sum(set.total))", 62},
+		{"count(any(red > 12))", 2},
+		{"count(all(blue <= 6))", 4},
+		{"sum(count(set.index > 1))", 9},
+		{"min(game.id * 10 + game.sets)", 13},
+		{"avg(sets)", 2.8},
+	}
+
+	for _, test := range tests {
+		nodes, errCompile := compileQuery(test.query, scopeGames, b)
+		if errCompile != nil {
+			t.Errorf("%q: unexpected error %v", test.query, errCompile)
+			continue
+		}
+		if got := nodes[0].eval(queryEnv{games: games}); got != test.want {
+			t.Errorf("%q: got %v, want %v", test.query, got, test.want)
+		}
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	b, _ := parseBag(defaultBag)
+
+	tests := []struct {
+		query string
+		scope queryScope
+		want  string
+	}{
+		// Scopes.
+		{"id > 1", scopeGames, "at column 1: unknown name id, use game properties in a function like sum(id)"},
+		{"red > 1", scopeGame, "at column 1: color red is counted in sets, use it in a function like max(red) or any(red > 0)"},
+		{"set.index > 1", scopeGame, "at column 1: unknown name set.index"},
+		{"any(sum(red) > 1)", scopeGame, "at column 5: sum cannot be used on a set, which has nothing to aggregate"},
+		{"purple > 1", scopeSet, "at column 1: unknown name purple"},
+		// Types.
+		{"1 + (1 == 1)", scopeGames, "at column 3: + needs two numbers"},
+		{"1 == 1 and 2", scopeGames, "at column 8: and needs two predicates"},
+		{"not 1", scopeGames, "at column 6: not needs a predicate, got a number"},
+		{"-(1 == 1)", scopeGames, "at column 10: - needs a number, got a predicate"},
+		{"sum(id == 1)", scopeGames, "at column 12: sum needs a number, got a predicate"},
+		{"count(id)", scopeGames, "at column 9: count needs a predicate, got a number"},
+		// Syntax.
+		{"1 < 2 < 3", scopeGames, "at column 7: unexpected \"<\""},
+		{"median(id)", scopeGames, "at column 1: unknown function median, expected one of sum, min, max, avg, count, any, all"},
+		{"sum(id", scopeGames, "at column 7: expected \")\", got the end of the query"},
+		{"1 +", scopeGames, "at column 4: unexpected end of the query"},
+		{"1 % 2", scopeGames, "at column 3: unexpected character '%'"},
+	}
+
+	for _, test := range tests {
+		_, errCompile := compileQuery(test.query, test.scope, b)
+		if errCompile == nil {
+			t.Errorf("%q: got no error, want %q", test.query, test.want)
+		} else if errCompile.Error() != test.want {
+			t.Errorf("%q: got error %q, want %q", test.query, errCompile, test.want)
+		}
+	}
+}
+
+func TestRunQueryLabels(t *testing.T) {
+	b, _ := parseBag(defaultBag)
+	games := testGames(t, b)
+
+	var out bytes.Buffer
+	if errQuery := runQuery(&out, games, b, "all(red <= 12)", "sum(id),count( ) ,  max(sum( total ))"); errQuery != nil {
+		t.Fatalf("unexpected error %v", errQuery)
+	}
+
+	want := "sum(id) = 8\ncount( ) = 3\nmax(sum( total )) = 18\n"
+	if got := out.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
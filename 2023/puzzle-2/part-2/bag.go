@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultColors are the colors of the puzzle, in the syntax of parseColors.
+const defaultColors = "red,green,blue"
+
+// bag holds the number of cubes of each color in the bag the cubes are drawn from.
+// Its colors are the only ones games may show. Only the colors matter to find the fewest possible cubes,
+// so a bag may also be built from colors alone, without cubes.
+type bag map[color]int
+
+// parseColors builds a bag without cubes from a comma separated list of colors.
+// It returns an error if a color is empty or given twice.
+func parseColors(s string) (bag, error) {
+	b := make(bag)
+
+	for _, rawColor := range strings.Split(s, ",") {
+		c := color(strings.TrimSpace(rawColor))
+		if c == "" {
+			return nil, fmt.Errorf("empty color in %q", s)
+		}
+		if _, exists := b[c]; exists {
+			return nil, fmt.Errorf("color %s given twice", c)
+		}
+		b[c] = 0
+	}
+
+	return b, nil
+}
+
+// parseBag parses a bag written like a set of cubes, as in "12 red, 13 green, 14 blue".
+// It returns an error if a count is invalid or if a color is given twice.
+func parseBag(s string) (bag, error) {
+	b := make(bag)
+
+	for _, rawCubes := range strings.Split(s, ",") {
+		fields := strings.Fields(rawCubes)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid cubes %q, expected a count and a color", strings.TrimSpace(rawCubes))
+		}
+
+		count, errCount := strconv.Atoi(fields[0])
+		if errCount != nil || count < 0 {
+			return nil, fmt.Errorf("invalid count %q", fields[0])
+		}
+
+		c := color(fields[1])
+		if _, exists := b[c]; exists {
+			return nil, fmt.Errorf("color %s given twice", c)
+		}
+		b[c] = count
+	}
+
+	return b, nil
+}
+
+// readBagFile reads a bag from a file where each line holds cubes in the syntax of parseBag.
+// Blank lines and lines starting with # are ignored.
+func readBagFile(path string) (bag, error) {
+	file, errOpen := os.Open(path)
+	if errOpen != nil {
+		return nil, fmt.Errorf("cannot read bag: %w", errOpen)
+	}
+	defer file.Close()
+
+	b := make(bag)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		cubes, errParse := parseBag(line)
+		if errParse != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNumber, errParse)
+		}
+
+		for c, count := range cubes {
+			if _, exists := b[c]; exists {
+				return nil, fmt.Errorf("%s:%d: color %s given twice", path, lineNumber, c)
+			}
+			b[c] = count
+		}
+	}
+
+	if errScan := scanner.Err(); errScan != nil {
+		return nil, fmt.Errorf("cannot read bag: %w", errScan)
+	}
+
+	if len(b) == 0 {
+		return nil, fmt.Errorf("%s: empty bag", path)
+	}
+
+	return b, nil
+}
+
+// colors returns the colors of the bag, sorted.
+func (b bag) colors() []color {
+	colors := []color{}
+	for c := range b {
+		colors = append(colors, c)
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		return colors[i] < colors[j]
+	})
+
+	return colors
+}
+
+// declares returns an error if the game shows a color which is not in the bag.
+func (b bag) declares(game *game) error {
+	for _, set := range game.sets {
+		for _, cubes := range set {
+			if _, exists := b[cubes.color]; !exists {
+				return fmt.Errorf("game %d: color %s is not in the bag", game.id, cubes.color)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+)
+
+const inputFile = "input.txt"
+
+type color string
+
+type cubes struct {
+	count int
+	color color
+}
+
+type set []cubes
+
+func (set *set) countColors() map[color]int {
+	counts := make(map[color]int)
+
+	for _, cubes := range []cubes(*set) {
+		counts[cubes.color] += cubes.count
+	}
+
+	return counts
+}
+
+type game struct {
+	id   int
+	sets []set
+}
+
+func (game *game) fewestPossibleCubes() bag {
+	fewest := make(bag)
+	for _, set := range game.sets {
+		for c, count := range set.countColors() {
+			if count > fewest[c] {
+				fewest[c] = count
+			}
+		}
+	}
+
+	return fewest
+}
+
+// power multiplies the number of cubes of each of the given colors in the bag.
+// It is 0 if the bag lacks one of them.
+func (b bag) power(colors []color) int {
+	pow := 1
+	for _, c := range colors {
+		pow *= b[c]
+	}
+
+	return pow
+}
+
+func main() {
+	colors := flag.String("colors", defaultColors, "comma separated colors of the cubes, all of them counting in the power of a set")
+	bagFile := flag.String("bag-file", "", "file holding the cubes in the bag, like \"12 red, 13 green, 14 blue\", whose colors replace the -colors flag")
+	strict := flag.Bool("strict", false, "reject game records which do not follow exactly the format of the puzzle")
+	flag.Parse()
+
+	b, errBag := parseColors(*colors)
+	if *bagFile != "" {
+		// Silently preferring one of them would hide a mistake in the command line.
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "colors" {
+				log.Fatal("-bag-file cannot be combined with -colors, the bag file already lists the colors")
+			}
+		})
+		b, errBag = readBagFile(*bagFile)
+	}
+	if errBag != nil {
+		log.Fatal(errBag)
+	}
+
+	lines, errRead := input.ReadLines(inputFile)
+	if errRead != nil {
+		log.Fatal(errRead)
+	}
+
+	powSum := 0
+	for i, line := range lines {
+		game, errParse := parseGameLine(line, b, *strict)
+		if errParse != nil {
+			log.Fatalf("line %d: %v", i+1, errParse)
+		}
+
+		if errColors := b.declares(game); errColors != nil {
+			log.Fatal(errColors)
+		}
+
+		pow := game.fewestPossibleCubes().power(b.colors())
+		powSum += pow
+	}
+
+	solution.Print(powSum)
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A game record follows the grammar:
+//
+//	record := prefix id ":" set (";" set)*
+//	set    := cubes ("," cubes)*
+//	cubes  := count color
+//
+// where the prefix and colors are words, and the id and counts are numbers written with 0 to 9. By default,
+// any whitespace may surround the tokens, the prefix may be any word, and colors are matched regardless of case
+// and of a plural "s", as in "1 Blues". In strict mode, records must read exactly like
+// "Game 1: 3 blue, 4 red; 1 red", with colors spelled like in the bag.
+
+// recordTokenKind is the kind of a token of a game record.
+type recordTokenKind int
+
+const (
+	tokenWord recordTokenKind = iota
+	tokenNumber
+	tokenColon
+	tokenComma
+	tokenSemicolon
+	tokenEnd
+)
+
+// String names the kind of token in error messages.
+func (k recordTokenKind) String() string {
+	return [...]string{"a word", "a number", `":"`, `","`, `";"`, "the end of the line"}[k]
+}
+
+// recordToken is a token of a game record.
+type recordToken struct {
+	kind   recordTokenKind
+	text   string
+	column int    // Column of the token in the line, from 1.
+	gap    string // Whitespace before the token.
+}
+
+// parseError is an error at a given column of a game record.
+type parseError struct {
+	column int
+	msg    string
+}
+
+// Error writes the column with the message.
+func (e *parseError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.column, e.msg)
+}
+
+// tokenizeRecord splits a game record into tokens, the last one always being the end of the line.
+func tokenizeRecord(line string) ([]recordToken, error) {
+	tokens := []recordToken{}
+
+	runes := []rune(line)
+	gapStart := 0
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		if unicode.IsSpace(r) {
+			i++
+			continue
+		}
+
+		start := i
+		token := recordToken{column: start + 1, gap: string(runes[gapStart:start])}
+		switch {
+		case unicode.IsLetter(r):
+			for i < len(runes) && unicode.IsLetter(runes[i]) {
+				i++
+			}
+			token.kind = tokenWord
+		case r >= '0' && r <= '9':
+			for i < len(runes) && runes[i] >= '0' && runes[i] <= '9' {
+				i++
+			}
+			token.kind = tokenNumber
+		case unicode.IsDigit(r):
+			return nil, &parseError{column: start + 1, msg: fmt.Sprintf("non-ASCII digit %q, numbers must be written with 0 to 9", r)}
+		case r == ':':
+			i++
+			token.kind = tokenColon
+		case r == ',':
+			i++
+			token.kind = tokenComma
+		case r == ';':
+			i++
+			token.kind = tokenSemicolon
+		default:
+			return nil, &parseError{column: start + 1, msg: fmt.Sprintf("unexpected character %q", r)}
+		}
+
+		token.text = string(runes[start:i])
+		tokens = append(tokens, token)
+		gapStart = i
+	}
+
+	return append(tokens, recordToken{kind: tokenEnd, column: len(runes) + 1, gap: string(runes[gapStart:])}), nil
+}
+
+// recordParser parses the tokens of a game record.
+type recordParser struct {
+	tokens []recordToken
+	pos    int
+	strict bool
+	bag    bag
+}
+
+// next consumes the next token, which must be of the given kind. In strict mode, it must also follow the given gap.
+func (p *recordParser) next(kind recordTokenKind, gap string) (recordToken, error) {
+	token := p.tokens[p.pos]
+	if token.kind != kind {
+		got := token.kind.String()
+		if token.kind != tokenEnd {
+			got = fmt.Sprintf("%q", token.text)
+		}
+		return token, &parseError{column: token.column, msg: fmt.Sprintf("expected %s, got %s", kind, got)}
+	}
+
+	if p.strict && token.gap != gap {
+		column := token.column - len([]rune(token.gap))
+		if gap == "" {
+			return token, &parseError{column: column, msg: fmt.Sprintf("unexpected whitespace before %s", kind)}
+		}
+		return token, &parseError{column: column, msg: fmt.Sprintf("expected a single space before %s", kind)}
+	}
+
+	p.pos++
+	return token, nil
+}
+
+// number converts a number token, which must fit in an int.
+func (p *recordParser) number(token recordToken) (int, error) {
+	n, errAtoi := strconv.Atoi(token.text)
+	if errAtoi != nil {
+		return 0, &parseError{column: token.column, msg: fmt.Sprintf("number %s is too large", token.text)}
+	}
+
+	return n, nil
+}
+
+// color returns the color of the bag a color token names. Outside of strict mode, the case and a plural "s"
+// are ignored. Colors missing from the bag are returned as written, to be reported by bag.declares.
+func (p *recordParser) color(token recordToken) color {
+	if p.strict {
+		return color(token.text)
+	}
+
+	word := strings.ToLower(token.text)
+	for _, candidate := range []string{token.text, word, strings.TrimSuffix(word, "s")} {
+		if _, exists := p.bag[color(candidate)]; exists {
+			return color(candidate)
+		}
+	}
+
+	return color(token.text)
+}
+
+// parseRecord parses a whole record.
+func (p *recordParser) parseRecord() (*game, error) {
+	prefix, errPrefix := p.next(tokenWord, "")
+	if errPrefix != nil {
+		return nil, errPrefix
+	}
+	if p.strict && prefix.text != "Game" {
+		return nil, &parseError{column: prefix.column, msg: fmt.Sprintf(`expected "Game", got %q`, prefix.text)}
+	}
+
+	idToken, errID := p.next(tokenNumber, " ")
+	if errID != nil {
+		return nil, errID
+	}
+	id, errNumber := p.number(idToken)
+	if errNumber != nil {
+		return nil, errNumber
+	}
+
+	if _, errColon := p.next(tokenColon, ""); errColon != nil {
+		return nil, errColon
+	}
+
+	g := &game{id: id}
+	for {
+		s, errSet := p.parseSet()
+		if errSet != nil {
+			return nil, errSet
+		}
+		g.sets = append(g.sets, s)
+
+		if p.tokens[p.pos].kind != tokenSemicolon {
+			break
+		}
+		if _, errSemicolon := p.next(tokenSemicolon, ""); errSemicolon != nil {
+			return nil, errSemicolon
+		}
+	}
+
+	if _, errEnd := p.next(tokenEnd, ""); errEnd != nil {
+		if parseErr, ok := errEnd.(*parseError); ok && p.tokens[p.pos].kind != tokenEnd {
+			parseErr.msg = fmt.Sprintf(`expected ",", ";" or the end of the line, got %q`, p.tokens[p.pos].text)
+		} else if ok {
+			parseErr.msg = "unexpected whitespace at the end of the line"
+		}
+		return nil, errEnd
+	}
+
+	return g, nil
+}
+
+// parseSet parses the cubes of a set, separated by commas.
+func (p *recordParser) parseSet() (set, error) {
+	s := set{}
+	for {
+		countToken, errCount := p.next(tokenNumber, " ")
+		if errCount != nil {
+			return nil, errCount
+		}
+		count, errNumber := p.number(countToken)
+		if errNumber != nil {
+			return nil, errNumber
+		}
+
+		colorToken, errColor := p.next(tokenWord, " ")
+		if errColor != nil {
+			return nil, errColor
+		}
+		s = append(s, cubes{count: count, color: p.color(colorToken)})
+
+		if p.tokens[p.pos].kind != tokenComma {
+			return s, nil
+		}
+		if _, errComma := p.next(tokenComma, ""); errComma != nil {
+			return nil, errComma
+		}
+	}
+}
+
+// parseGameLine parses a game record, whose colors are matched against those of the bag.
+// Errors give the column where the record stops following the grammar.
+func parseGameLine(line string, b bag, strict bool) (*game, error) {
+	tokens, errTokens := tokenizeRecord(line)
+	if errTokens != nil {
+		return nil, errTokens
+	}
+
+	p := &recordParser{
+		tokens: tokens,
+		strict: strict,
+		bag:    b,
+	}
+
+	return p.parseRecord()
+}
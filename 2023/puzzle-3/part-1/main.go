@@ -0,0 +1,54 @@
+package main
+
+import (
+	"log"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/geom"
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+)
+
+const inputFile = "input.txt"
+
+// partNumber is a number which is potentially part of the engine plan.
+type partNumber struct {
+	num    int
+	points []geom.Point
+}
+
+// isIncluded returns true if the part number is adjacent to at least one symbol of the schematic.
+func (pn *partNumber) isIncluded(s schematic) bool {
+	for _, cell := range neighbors(pn.points) {
+		if _, isSymbol := s.symbolAt[cell]; isSymbol {
+			return true
+		}
+	}
+
+	return false
+}
+
+// areAdjacent returns true if two points are adjacent, even diagonally.
+func areAdjacent(p1, p2 geom.Point) bool {
+	return geom.Chebyshev(p1, p2) <= 1
+}
+
+func main() {
+	lines, errRead := input.ReadLines(inputFile)
+	if errRead != nil {
+		log.Fatal(errRead)
+	}
+
+	s, errSchematic := parseSchematic(lines)
+	if errSchematic != nil {
+		log.Fatal(errSchematic)
+	}
+
+	sum := 0
+	for _, pn := range s.numbers {
+		if pn.isIncluded(s) {
+			sum += pn.num
+		}
+	}
+
+	solution.Print(sum)
+}
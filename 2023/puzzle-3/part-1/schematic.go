@@ -0,0 +1,87 @@
+package main
+
+import (
+	"github.com/maaxleq/advent-of-code-2023/lib/geom"
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+)
+
+// partSymbol represents a symbol in the engine plan.
+type partSymbol struct {
+	point  geom.Point
+	symbol rune
+}
+
+// schematic is a sparse representation of the engine plan: its part numbers and symbols, indexed by the cells
+// they cover so that finding what is next to a cell takes constant time. Empty cells are not stored.
+type schematic struct {
+	numbers  []partNumber
+	symbols  []partSymbol
+	numberAt map[geom.Point]int // Index in numbers of the number covering each cell with a digit.
+	symbolAt map[geom.Point]int // Index in symbols of the symbol on each cell with one.
+}
+
+// parseSchematic builds the schematic of an engine plan, where dots are empty cells,
+// runs of digits are part numbers and anything else is a symbol. Every run of digits is a part number,
+// even one reading 0. It returns an error if the lines of the plan are not all the same length.
+func parseSchematic(lines []string) (schematic, error) {
+	plan, errPlan := grid.Runes(lines)
+	if errPlan != nil {
+		return schematic{}, errPlan
+	}
+
+	s := schematic{
+		numberAt: make(map[geom.Point]int),
+		symbolAt: make(map[geom.Point]int),
+	}
+
+	for y := 0; y < plan.Height(); y++ {
+		current := partNumber{}
+		endNumber := func() {
+			if len(current.points) > 0 {
+				for _, p := range current.points {
+					s.numberAt[p] = len(s.numbers)
+				}
+				s.numbers = append(s.numbers, current)
+				current = partNumber{}
+			}
+		}
+
+		for x, r := range plan.Row(y) {
+			p := geom.Point{X: x, Y: y}
+
+			if r >= '0' && r <= '9' {
+				current.points = append(current.points, p)
+				current.num = current.num*10 + int(r-'0')
+				continue
+			}
+
+			endNumber()
+			if r != '.' {
+				s.symbolAt[p] = len(s.symbols)
+				s.symbols = append(s.symbols, partSymbol{point: p, symbol: r})
+			}
+		}
+		endNumber()
+	}
+
+	return s, nil
+}
+
+// neighbors returns the cells adjacent to at least one of the points, excluding the points themselves.
+// The points must be consecutive cells of a row, like the digits of a part number or a single symbol:
+// their neighbors are then the ring of cells around them, which it walks without looking at the points.
+func neighbors(points []geom.Point) []geom.Point {
+	lo, hi := points[0], points[len(points)-1]
+
+	// Row after row, like a scan of the box around the points.
+	cells := make([]geom.Point, 0, 2*(hi.X-lo.X+3)+2)
+	for x := lo.X - 1; x <= hi.X+1; x++ {
+		cells = append(cells, geom.Point{X: x, Y: lo.Y - 1})
+	}
+	cells = append(cells, geom.Point{X: lo.X - 1, Y: lo.Y}, geom.Point{X: hi.X + 1, Y: lo.Y})
+	for x := lo.X - 1; x <= hi.X+1; x++ {
+		cells = append(cells, geom.Point{X: x, Y: lo.Y + 1})
+	}
+
+	return cells
+}
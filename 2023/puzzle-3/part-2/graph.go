@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// graphNode is a part number or a symbol of the schematic, placed at its first cell.
+type graphNode struct {
+	ID     string `json:"id"`
+	Kind   string `json:"kind"` // "number" or "symbol".
+	Label  string `json:"label"`
+	X      int    `json:"x"`
+	Y      int    `json:"y"`
+	Width  int    `json:"width"`  // Number of cells covered, the digits of a part number.
+	Degree int    `json:"degree"` // Number of adjacent nodes, 0 for orphan part numbers and lone symbols.
+	Gear   bool   `json:"gear"`   // Whether the symbol is a gear whose rule accepts its part numbers.
+	Ratio  int    `json:"ratio"`  // Ratio of the gear, 0 for other nodes.
+}
+
+// graphEdge links a part number to a symbol adjacent to it.
+type graphEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// graph is the bipartite graph of the adjacency between the part numbers and the symbols of a schematic.
+type graph struct {
+	Nodes []graphNode `json:"nodes"`
+	Edges []graphEdge `json:"edges"`
+}
+
+// graphFormats holds the writers of the graph which can be selected by name.
+var graphFormats = map[string]func(w io.Writer, g graph) error{
+	"dot":     writeDOT,
+	"graphml": writeGraphML,
+	"json":    writeGraphJSON,
+}
+
+// graphFormatNames returns the names of the graph formats, sorted.
+func graphFormatNames() []string {
+	names := []string{}
+	for name := range graphFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// numberID and symbolID return the IDs of the nodes of the part numbers and symbols, by index in the schematic.
+func numberID(i int) string { return "n" + strconv.Itoa(i) }
+func symbolID(i int) string { return "s" + strconv.Itoa(i) }
+
+// buildGraph builds the adjacency graph of the schematic, with the gears of the symbols having a rule.
+// Nodes are part numbers then symbols, in the order of the schematic, and edges are sorted by symbol then part number.
+func buildGraph(s schematic, rules ruleSet) graph {
+	g := graph{Nodes: make([]graphNode, 0, len(s.numbers)+len(s.symbols))}
+
+	for i, pn := range s.numbers {
+		g.Nodes = append(g.Nodes, graphNode{
+			ID:    numberID(i),
+			Kind:  "number",
+			Label: strconv.Itoa(pn.num),
+			X:     pn.points[0].X,
+			Y:     pn.points[0].Y,
+			Width: len(pn.points),
+		})
+	}
+
+	for i, ps := range s.symbols {
+		adjacent := ps.adjacentParts(s)
+		sort.Ints(adjacent)
+
+		for _, j := range adjacent {
+			g.Edges = append(g.Edges, graphEdge{Source: numberID(j), Target: symbolID(i)})
+			g.Nodes[j].Degree++
+		}
+
+		node := graphNode{
+			ID:     symbolID(i),
+			Kind:   "symbol",
+			Label:  string(ps.symbol),
+			X:      ps.point.X,
+			Y:      ps.point.Y,
+			Width:  1,
+			Degree: len(adjacent),
+		}
+		if rule, exists := rules[ps.symbol]; exists && rule.matches(len(adjacent)) {
+			gr := gear{partSymbol: ps, rule: rule}
+			node.Gear, node.Ratio = true, gr.ratio(s)
+		}
+		g.Nodes = append(g.Nodes, node)
+	}
+
+	return g
+}
+
+// writeDOT writes the graph in the DOT language of Graphviz. Nodes are pinned at their coordinates,
+// y pointing down like in the schematic, so that neato -n draws the graph over the plan.
+func writeDOT(w io.Writer, g graph) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "graph schematic {")
+	for _, n := range g.Nodes {
+		shape := "ellipse"
+		if n.Kind == "symbol" {
+			shape = "box"
+		}
+		// Graphviz has its own width attribute, in inches.
+		fmt.Fprintf(bw, "  %s [label=%s, kind=%s, shape=%s, x=%d, y=%d, width_cells=%d, degree=%d, gear=%t, ratio=%d, pos=\"%d,%d!\"];\n",
+			n.ID, quoteDOT(n.Label), n.Kind, shape, n.X, n.Y, n.Width, n.Degree, n.Gear, n.Ratio, n.X*72, -n.Y*72)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "  %s -- %s;\n", e.Source, e.Target)
+	}
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+// quoteDOT quotes a string for the DOT language, which only escapes double quotes and backslashes.
+func quoteDOT(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// graphMLKeys are the attributes of the nodes in GraphML, with their types.
+var graphMLKeys = [][2]string{
+	{"kind", "string"},
+	{"label", "string"},
+	{"x", "int"},
+	{"y", "int"},
+	{"width", "int"},
+	{"degree", "int"},
+	{"gear", "boolean"},
+	{"ratio", "int"},
+}
+
+// writeGraphML writes the graph in GraphML, with the attributes of the nodes as data keys.
+func writeGraphML(w io.Writer, g graph) error {
+	bw := bufio.NewWriter(w)
+	escape := func(s string) string {
+		var sb strings.Builder
+		xml.EscapeText(&sb, []byte(s))
+		return sb.String()
+	}
+
+	fmt.Fprintln(bw, xml.Header+`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+	for _, key := range graphMLKeys {
+		fmt.Fprintf(bw, "  <key id=%q for=\"node\" attr.name=%q attr.type=%q/>\n", key[0], key[0], key[1])
+	}
+	fmt.Fprintln(bw, `  <graph id="schematic" edgedefault="undirected">`)
+	for _, n := range g.Nodes {
+		fmt.Fprintf(bw, "    <node id=%q>\n", n.ID)
+		values := []string{n.Kind, escape(n.Label), strconv.Itoa(n.X), strconv.Itoa(n.Y), strconv.Itoa(n.Width), strconv.Itoa(n.Degree),
+			strconv.FormatBool(n.Gear), strconv.Itoa(n.Ratio)}
+		for i, key := range graphMLKeys {
+			fmt.Fprintf(bw, "      <data key=%q>%s</data>\n", key[0], values[i])
+		}
+		fmt.Fprintln(bw, "    </node>")
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "    <edge source=%q target=%q/>\n", e.Source, e.Target)
+	}
+	fmt.Fprintln(bw, "  </graph>")
+	fmt.Fprintln(bw, "</graphml>")
+
+	return bw.Flush()
+}
+
+// writeGraphJSON writes the graph as a JSON object with the lists of nodes and edges.
+func writeGraphJSON(w io.Writer, g graph) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(g)
+}
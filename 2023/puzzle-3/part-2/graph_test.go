@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+// testGraph returns the graph of a schematic with a gear, an orphan part number (114)
+// and a lone symbol ("), under the rules of the puzzle.
+func testGraph(t *testing.T) graph {
+	t.Helper()
+
+	s, errSchematic := parseSchematic([]string{
+		"467..114..",
+		"...*......",
+		"..35....\".",
+	})
+	if errSchematic != nil {
+		t.Fatalf("unexpected error %v", errSchematic)
+	}
+	rules, errRules := parseRules(defaultRules)
+	if errRules != nil {
+		t.Fatalf("unexpected error %v", errRules)
+	}
+
+	return buildGraph(s, rules)
+}
+
+func TestWriteGraph(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"dot", `graph schematic {
+  n0 [label="467", kind=number, shape=ellipse, x=0, y=0, width_cells=3, degree=1, gear=false, ratio=0, pos="0,0!"];
+  n1 [label="114", kind=number, shape=ellipse, x=5, y=0, width_cells=3, degree=0, gear=false, ratio=0, pos="360,0!"];
+  n2 [label="35", kind=number, shape=ellipse, x=2, y=2, width_cells=2, degree=1, gear=false, ratio=0, pos="144,-144!"];
+  s0 [label="*", kind=symbol, shape=box, x=3, y=1, width_cells=1, degree=2, gear=true, ratio=16345, pos="216,-72!"];
+  s1 [label="\"", kind=symbol, shape=box, x=8, y=2, width_cells=1, degree=0, gear=false, ratio=0, pos="576,-144!"];
+  n0 -- s0;
+  n2 -- s0;
+}
+`},
+		{"graphml", `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="kind" for="node" attr.name="kind" attr.type="string"/>
+  <key id="label" for="node" attr.name="label" attr.type="string"/>
+  <key id="x" for="node" attr.name="x" attr.type="int"/>
+  <key id="y" for="node" attr.name="y" attr.type="int"/>
+  <key id="width" for="node" attr.name="width" attr.type="int"/>
+  <key id="degree" for="node" attr.name="degree" attr.type="int"/>
+  <key id="gear" for="node" attr.name="gear" attr.type="boolean"/>
+  <key id="ratio" for="node" attr.name="ratio" attr.type="int"/>
+  <graph id="schematic" edgedefault="undirected">
+    <node id="n0">
+      <data key="kind">number</data>
+      <data key="label">467</data>
+      <data key="x">0</data>
+      <data key="y">0</data>
+      <data key="width">3</data>
+      <data key="degree">1</data>
+      <data key="gear">false</data>
+      <data key="ratio">0</data>
+    </node>
+    <node id="n1">
+      <data key="kind">number</data>
+      <data key="label">114</data>
+      <data key="x">5</data>
+      <data key="y">0</data>
+      <data key="width">3</data>
+      <data key="degree">0</data>
+      <data key="gear">false</data>
+      <data key="ratio">0</data>
+    </node>
+    <node id="n2">
+      <data key="kind">number</data>
+      <data key="label">35</data>
+      <data key="x">2</data>
+      <data key="y">2</data>
+      <data key="width">2</data>
+      <data key="degree">1</data>
+      <data key="gear">false</data>
+      <data key="ratio">0</data>
+    </node>
+    <node id="s0">
+      <data key="kind">symbol</data>
+      <data key="label">*</data>
+      <data key="x">3</data>
+      <data key="y">1</data>
+      <data key="width">1</data>
+      <data key="degree">2</data>
+      <data key="gear">true</data>
+      <data key="ratio">16345</data>
+    </node>
+    <node id="s1">
+      <data key="kind">symbol</data>
+      <data key="label">&#34;</data>
+      <data key="x">8</data>
+      <data key="y">2</data>
+      <data key="width">1</data>
+      <data key="degree">0</data>
+      <data key="gear">false</data>
+      <data key="ratio">0</data>
+    </node>
+    <edge source="n0" target="s0"/>
+    <edge source="n2" target="s0"/>
+  </graph>
+</graphml>
+`},
+		{"json", `{
+  "nodes": [
+    {
+      "id": "n0",
+      "kind": "number",
+      "label": "467",
+      "x": 0,
+      "y": 0,
+      "width": 3,
+      "degree": 1,
+      "gear": false,
+      "ratio": 0
+    },
+    {
+      "id": "n1",
+      "kind": "number",
+      "label": "114",
+      "x": 5,
+      "y": 0,
+      "width": 3,
+      "degree": 0,
+      "gear": false,
+      "ratio": 0
+    },
+    {
+      "id": "n2",
+      "kind": "number",
+      "label": "35",
+      "x": 2,
+      "y": 2,
+      "width": 2,
+      "degree": 1,
+      "gear": false,
+      "ratio": 0
+    },
+    {
+      "id": "s0",
+      "kind": "symbol",
+      "label": "*",
+      "x": 3,
+      "y": 1,
+      "width": 1,
+      "degree": 2,
+      "gear": true,
+      "ratio": 16345
+    },
+    {
+      "id": "s1",
+      "kind": "symbol",
+      "label": "\"",
+      "x": 8,
+      "y": 2,
+      "width": 1,
+      "degree": 0,
+      "gear": false,
+      "ratio": 0
+    }
+  ],
+  "edges": [
+    {
+      "source": "n0",
+      "target": "s0"
+    },
+    {
+      "source": "n2",
+      "target": "s0"
+    }
+  ]
+}
+`},
+	}
+
+	g := testGraph(t)
+	for _, test := range tests {
+		var out bytes.Buffer
+		if errWrite := graphFormats[test.format](&out, g); errWrite != nil {
+			t.Errorf("%s: unexpected error %v", test.format, errWrite)
+			continue
+		}
+		if got := out.String(); got != test.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", test.format, got, test.want)
+		}
+	}
+}
+
+func TestGraphOrphansAndLoneSymbols(t *testing.T) {
+	g := testGraph(t)
+
+	isolated := []string{}
+	for _, n := range g.Nodes {
+		if n.Degree == 0 {
+			isolated = append(isolated, n.Kind+" "+n.Label)
+		}
+	}
+
+	if len(isolated) != 2 || isolated[0] != "number 114" || isolated[1] != `symbol "` {
+		t.Errorf("got isolated nodes %q, want the number 114 and the symbol \"", isolated)
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/geom"
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+)
+
+const inputFile = "input.txt"
+
+// partNumber is a number which is potentially part of the engine plan.
+type partNumber struct {
+	num    int
+	points []geom.Point
+}
+
+// adjacentParts returns the indexes in the schematic of the distinct part numbers adjacent to the symbol.
+func (ps *partSymbol) adjacentParts(s schematic) []int {
+	adjacent := []int{}
+
+	for _, cell := range neighbors([]geom.Point{ps.point}) {
+		i, isDigit := s.numberAt[cell]
+		if !isDigit || slices.Contains(adjacent, i) {
+			continue
+		}
+		adjacent = append(adjacent, i)
+	}
+
+	return adjacent
+}
+
+// adjacentNumbers returns the values of the distinct part numbers of the schematic adjacent to the symbol.
+func (ps *partSymbol) adjacentNumbers(s schematic) []int {
+	adjacent := ps.adjacentParts(s)
+
+	nums := make([]int, len(adjacent))
+	for j, i := range adjacent {
+		nums[j] = s.numbers[i].num
+	}
+
+	return nums
+}
+
+// gear represents a gear in the engine plan: a symbol with the rule it follows. In the puzzle,
+// gears are the "*" adjacent to exactly two part numbers, other rules extend them to other symbols.
+type gear struct {
+	partSymbol
+	rule symbolRule
+}
+
+// gears returns the symbols of the schematic which have a rule, in the order of the schematic.
+func gears(s schematic, rules ruleSet) []gear {
+	gs := []gear{}
+	for _, ps := range s.symbols {
+		if rule, exists := rules[ps.symbol]; exists {
+			gs = append(gs, gear{partSymbol: ps, rule: rule})
+		}
+	}
+
+	return gs
+}
+
+// ratio combines the values of the part numbers of the schematic adjacent to the gear with the combiner of
+// its rule, or returns 0 if the rule does not accept that many part numbers. With the rule of the puzzle,
+// it is the product of exactly two part numbers.
+func (g *gear) ratio(s schematic) int {
+	nums := g.adjacentNumbers(s)
+	if !g.rule.matches(len(nums)) {
+		return 0
+	}
+
+	return g.rule.combine(nums)
+}
+
+// areAdjacent returns true if two points are adjacent, even diagonally.
+func areAdjacent(p1, p2 geom.Point) bool {
+	return geom.Chebyshev(p1, p2) <= 1
+}
+
+func main() {
+	rawRules := flag.String("rules", defaultRules, "whitespace separated rules of the symbols which count, like \"*=2:product #>=1:sum\", with combiners among "+strings.Join(combinerNames(), ", "))
+	report := flag.Bool("report", false, "instead of solving the puzzle, report for each symbol its count, how many part numbers they are adjacent to and their total value")
+	graphFormat := flag.String("graph", "", "instead of solving the puzzle, write the adjacency graph of the part numbers and symbols, in one of "+strings.Join(graphFormatNames(), ", "))
+	flag.Parse()
+
+	rules, errRules := parseRules(*rawRules)
+	if errRules != nil {
+		log.Fatal(errRules)
+	}
+
+	lines, errRead := input.ReadLines(inputFile)
+	if errRead != nil {
+		log.Fatal(errRead)
+	}
+
+	s, errSchematic := parseSchematic(lines)
+	if errSchematic != nil {
+		log.Fatal(errSchematic)
+	}
+
+	if *graphFormat != "" {
+		writeGraph, exists := graphFormats[*graphFormat]
+		if !exists {
+			log.Fatalf("unknown graph format %q, expected one of %s", *graphFormat, strings.Join(graphFormatNames(), ", "))
+		}
+		if errGraph := writeGraph(os.Stdout, buildGraph(s, rules)); errGraph != nil {
+			log.Fatal(errGraph)
+		}
+		return
+	}
+
+	if *report {
+		if errReport := writeSymbolReport(os.Stdout, s, rules); errReport != nil {
+			log.Fatal(errReport)
+		}
+		return
+	}
+
+	sum := 0
+	for _, g := range gears(s, rules) {
+		sum += g.ratio(s)
+	}
+
+	solution.Print(sum)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// symbolStats gathers what is known about the symbols of a kind.
+type symbolStats struct {
+	count     int
+	counted   int         // Number of symbols matching their rule.
+	histogram map[int]int // Number of symbols by number of adjacent part numbers.
+	total     int         // Sum of the values of the symbols matching their rule.
+}
+
+// collectSymbolStats returns the stats of every kind of symbol of the schematic,
+// and of the symbols which have a rule but do not appear in it.
+func collectSymbolStats(s schematic, rules ruleSet) map[rune]*symbolStats {
+	stats := make(map[rune]*symbolStats)
+	get := func(symbol rune) *symbolStats {
+		if _, exists := stats[symbol]; !exists {
+			stats[symbol] = &symbolStats{histogram: make(map[int]int)}
+		}
+		return stats[symbol]
+	}
+
+	for symbol := range rules {
+		get(symbol)
+	}
+
+	for _, ps := range s.symbols {
+		nums := ps.adjacentNumbers(s)
+
+		st := get(ps.symbol)
+		st.count++
+		st.histogram[len(nums)]++
+		if rule, exists := rules[ps.symbol]; exists && rule.matches(len(nums)) {
+			st.counted++
+			st.total += rule.combine(nums)
+		}
+	}
+
+	return stats
+}
+
+// writeSymbolReport writes a line per kind of symbol, sorted, with its rule, how many symbols there are
+// and how many of them match the rule, their total value, and how many are adjacent to each number of part numbers.
+func writeSymbolReport(w io.Writer, s schematic, rules ruleSet) error {
+	stats := collectSymbolStats(s, rules)
+
+	symbols := []rune{}
+	for symbol := range stats {
+		symbols = append(symbols, symbol)
+	}
+	sort.Slice(symbols, func(i, j int) bool {
+		return symbols[i] < symbols[j]
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "symbol\trule\tcount\tcounted\ttotal\tneighbours")
+	for _, symbol := range symbols {
+		st := stats[symbol]
+
+		rule := "-"
+		if r, exists := rules[symbol]; exists {
+			rule = r.String()
+		}
+
+		counts := []int{}
+		for count := range st.histogram {
+			counts = append(counts, count)
+		}
+		sort.Ints(counts)
+		histogram := []string{}
+		for _, count := range counts {
+			histogram = append(histogram, fmt.Sprintf("%d:%d", count, st.histogram[count]))
+		}
+
+		fmt.Fprintf(tw, "%c\t%s\t%d\t%d\t%d\t%s\n", symbol, rule, st.count, st.counted, st.total, strings.Join(histogram, " "))
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultRules are the rules of the puzzle: a gear is a "*" adjacent to exactly two part numbers,
+// and its ratio is their product.
+const defaultRules = "*=2:product"
+
+// combiner computes the value of a symbol from the part numbers adjacent to it.
+type combiner func(nums []int) int
+
+// combiners holds the combiners which can be selected by name.
+var combiners = map[string]combiner{
+	"product": func(nums []int) int {
+		product := 1
+		for _, num := range nums {
+			product *= num
+		}
+		return product
+	},
+	"sum": func(nums []int) int {
+		sum := 0
+		for _, num := range nums {
+			sum += num
+		}
+		return sum
+	},
+	"max": func(nums []int) int {
+		if len(nums) == 0 {
+			return 0
+		}
+		return slices.Max(nums)
+	},
+}
+
+// combinerNames returns the names of the combiners, sorted.
+func combinerNames() []string {
+	names := []string{}
+	for name := range combiners {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// symbolRule tells how many part numbers a symbol must be adjacent to for it to count,
+// and how its value is computed from them.
+type symbolRule struct {
+	neighbors   int
+	atLeast     bool // The symbol may be adjacent to more than neighbors part numbers.
+	combineName string
+	combine     combiner
+}
+
+// String writes the rule in the syntax of parseRules, without the symbol.
+func (r symbolRule) String() string {
+	op := "="
+	if r.atLeast {
+		op = ">="
+	}
+
+	return fmt.Sprintf("%s%d:%s", op, r.neighbors, r.combineName)
+}
+
+// matches returns true if a symbol adjacent to the given number of part numbers counts.
+func (r symbolRule) matches(count int) bool {
+	if r.atLeast {
+		return count >= r.neighbors
+	}
+
+	return count == r.neighbors
+}
+
+// ruleSet maps symbols to their rules. Symbols without a rule do not count.
+type ruleSet map[rune]symbolRule
+
+// parseRules parses whitespace separated rules like "*=2:product" or "#>=1:sum": a symbol, the exact ("=")
+// or minimum (">=") number of adjacent part numbers, and the combiner of their values, product by default.
+func parseRules(s string) (ruleSet, error) {
+	rules := make(ruleSet)
+	for _, raw := range strings.Fields(s) {
+		symbol, size := utf8.DecodeRuneInString(raw)
+		if symbol == '.' || (symbol >= '0' && symbol <= '9') {
+			return nil, fmt.Errorf("invalid rule %q: %q is not a symbol", raw, symbol)
+		}
+		if _, exists := rules[symbol]; exists {
+			return nil, fmt.Errorf("invalid rule %q: symbol %q already has a rule", raw, symbol)
+		}
+
+		rule := symbolRule{combineName: "product"}
+		rest := raw[size:]
+		switch {
+		case strings.HasPrefix(rest, ">="):
+			rule.atLeast, rest = true, rest[len(">="):]
+		case strings.HasPrefix(rest, "="):
+			rest = rest[len("="):]
+		default:
+			return nil, fmt.Errorf("invalid rule %q: expected \"=\" or \">=\" after the symbol", raw)
+		}
+
+		rawCount, combineName, hasCombiner := strings.Cut(rest, ":")
+		count, errCount := strconv.Atoi(rawCount)
+		if errCount != nil || count < 0 {
+			return nil, fmt.Errorf("invalid rule %q: invalid number of part numbers %q", raw, rawCount)
+		}
+		rule.neighbors = count
+
+		if hasCombiner {
+			rule.combineName = combineName
+		}
+		combine, exists := combiners[rule.combineName]
+		if !exists {
+			return nil, fmt.Errorf("invalid rule %q: unknown combiner %q, expected one of %s", raw, rule.combineName, strings.Join(combinerNames(), ", "))
+		}
+		rule.combine = combine
+
+		rules[symbol] = rule
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/geom"
+)
+
+// generateSchematic returns the lines of a random engine plan of the given size, looking like the puzzle input,
+// to check and time the schematic on plans much larger than the input.
+func generateSchematic(width, height int) []string {
+	const symbols = "*#+$/@=%&-"
+	rng := rand.New(rand.NewSource(1))
+
+	lines := make([]string, height)
+	for y := range lines {
+		var sb strings.Builder
+		for sb.Len() < width {
+			switch r := rng.Intn(20); {
+			case r < 2:
+				length := min(1+rng.Intn(3), width-sb.Len())
+				sb.WriteByte(byte('1' + rng.Intn(9)))
+				for i := 1; i < length; i++ {
+					sb.WriteByte(byte('0' + rng.Intn(10)))
+				}
+			case r < 3:
+				sb.WriteByte(symbols[rng.Intn(len(symbols))])
+			default:
+				sb.WriteByte('.')
+			}
+		}
+		lines[y] = sb.String()
+	}
+
+	return lines
+}
+
+func TestParseSchematic(t *testing.T) {
+	s, errSchematic := parseSchematic([]string{
+		"467..114..",
+		"...*......",
+		"0.35..633.",
+	})
+	if errSchematic != nil {
+		t.Fatalf("unexpected error %v", errSchematic)
+	}
+
+	wantNumbers := []partNumber{
+		{467, []geom.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}},
+		{114, []geom.Point{{X: 5, Y: 0}, {X: 6, Y: 0}, {X: 7, Y: 0}}},
+		{0, []geom.Point{{X: 0, Y: 2}}},
+		{35, []geom.Point{{X: 2, Y: 2}, {X: 3, Y: 2}}},
+		{633, []geom.Point{{X: 6, Y: 2}, {X: 7, Y: 2}, {X: 8, Y: 2}}},
+	}
+	if !reflect.DeepEqual(s.numbers, wantNumbers) {
+		t.Errorf("numbers: got %v, want %v", s.numbers, wantNumbers)
+	}
+
+	wantSymbols := []partSymbol{{geom.Point{X: 3, Y: 1}, '*'}}
+	if !reflect.DeepEqual(s.symbols, wantSymbols) {
+		t.Errorf("symbols: got %v, want %v", s.symbols, wantSymbols)
+	}
+
+	for i, pn := range s.numbers {
+		for _, p := range pn.points {
+			if got := s.numberAt[p]; got != i {
+				t.Errorf("number at %v: got %d, want %d", p, got, i)
+			}
+		}
+	}
+	if len(s.numberAt) != 12 || len(s.symbolAt) != 1 || s.symbolAt[geom.Point{X: 3, Y: 1}] != 0 {
+		t.Errorf("got %d numbered cells and symbols at %v, want 12 and the symbol at (3, 1)", len(s.numberAt), s.symbolAt)
+	}
+}
+
+func TestParseSchematicRejectsRaggedRows(t *testing.T) {
+	if _, errSchematic := parseSchematic([]string{"...", ".."}); errSchematic == nil {
+		t.Error("got no error for rows of different lengths")
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	tests := [][]geom.Point{
+		{{X: 0, Y: 0}},
+		{{X: 4, Y: 7}},
+		{{X: 2, Y: 3}, {X: 3, Y: 3}},
+		{{X: 5, Y: 1}, {X: 6, Y: 1}, {X: 7, Y: 1}},
+	}
+
+	for _, points := range tests {
+		// Every cell of a box large enough, in the order of a scan.
+		want := []geom.Point{}
+		for y := -2; y <= 10; y++ {
+			for x := -2; x <= 10; x++ {
+				cell := geom.Point{X: x, Y: y}
+				own, adjacent := false, false
+				for _, p := range points {
+					own = own || cell == p
+					adjacent = adjacent || areAdjacent(cell, p)
+				}
+				if adjacent && !own {
+					want = append(want, cell)
+				}
+			}
+		}
+
+		if got := neighbors(points); !reflect.DeepEqual(got, want) {
+			t.Errorf("neighbors of %v: got %v, want %v", points, got, want)
+		}
+	}
+}
+
+func TestGenerateSchematic(t *testing.T) {
+	lines := generateSchematic(200, 50)
+	if len(lines) != 50 {
+		t.Fatalf("got %d lines, want 50", len(lines))
+	}
+	for y, line := range lines {
+		if len(line) != 200 {
+			t.Errorf("line %d: got %d cells, want 200", y+1, len(line))
+		}
+	}
+
+	s, errSchematic := parseSchematic(lines)
+	if errSchematic != nil {
+		t.Fatalf("unexpected error %v", errSchematic)
+	}
+	if len(s.numbers) == 0 || len(s.symbols) == 0 {
+		t.Errorf("got %d numbers and %d symbols, want some of both", len(s.numbers), len(s.symbols))
+	}
+}
+
+// BenchmarkSchematic parses a generated plan of 4 million cells and finds the symbols next to its numbers,
+// which takes time linear in the size of the plan.
+func BenchmarkSchematic(b *testing.B) {
+	lines := generateSchematic(2000, 2000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s, errSchematic := parseSchematic(lines)
+		if errSchematic != nil {
+			b.Fatal(errSchematic)
+		}
+
+		adjacent := 0
+		for _, pn := range s.numbers {
+			for _, cell := range neighbors(pn.points) {
+				if _, isSymbol := s.symbolAt[cell]; isSymbol {
+					adjacent++
+				}
+			}
+		}
+	}
+}
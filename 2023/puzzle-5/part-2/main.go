@@ -1,13 +1,17 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"log"
 	"math"
 	"os"
 	"strconv"
 	"strings"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/repl"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+	"github.com/maaxleq/advent-of-code-2023/lib/trace"
 )
 
 const inputFile = "input.txt"
@@ -105,6 +109,9 @@ func getLocationForSeed(intervals intervalMap, seed uint64) (uint64, error) {
 		if errMapping != nil {
 			return 0, fmt.Errorf("cannot get location for seed %d: %w", seed, errMapping)
 		}
+		if trace.Enabled() {
+			trace.Emit("hop", "seed", seed, "map", mapName, "from", currentNum, "to", newNum)
+		}
 
 		currentNum = newNum
 	}
@@ -112,24 +119,8 @@ func getLocationForSeed(intervals intervalMap, seed uint64) (uint64, error) {
 	return currentNum, nil
 }
 
-// readFileLines reads a file and returns its contents as an array of strings.
-func readFileLines(filePath string) ([]string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	return lines, scanner.Err()
-}
-
 func main() {
-	lines, errRead := readFileLines(inputFile)
+	lines, errRead := input.ReadLines(inputFile)
 	if errRead != nil {
 		log.Fatal(errRead)
 	}
@@ -139,6 +130,13 @@ func main() {
 		log.Fatal(errGet)
 	}
 
+	if repl.Enabled() {
+		if errRepl := repl.Run(os.Stdin, os.Stdout, "day 5 part 2", replCommands(seedIntervals, intervals)); errRepl != nil {
+			log.Fatal(errRepl)
+		}
+		return
+	}
+
 	var lowestLoc uint64 = math.MaxUint64
 
 	// Good old bruteforce
@@ -155,5 +153,5 @@ func main() {
 		}
 	}
 
-	fmt.Println(lowestLoc)
+	solution.Print(lowestLoc)
 }
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/repl"
+)
+
+// parseNumber parses a seed or a category number typed in the REPL.
+func parseNumber(s string) (uint64, error) {
+	num, errParse := strconv.ParseUint(s, 10, 64)
+	if errParse != nil {
+		return 0, fmt.Errorf("invalid number: %s", s)
+	}
+
+	return num, nil
+}
+
+// replCommands returns the commands of the REPL, evaluated against the parsed almanac.
+func replCommands(seedIntervals [][2]uint64, intervals intervalMap) []repl.Command {
+	return []repl.Command{
+		{
+			Name: "seeds",
+			Help: "list the seed intervals of the almanac, as start and length",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 0); errArgs != nil {
+					return "", errArgs
+				}
+
+				return fmt.Sprint(seedIntervals), nil
+			},
+		},
+		{
+			Name: "maps",
+			Help: "list the maps in the order they are applied",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 0); errArgs != nil {
+					return "", errArgs
+				}
+
+				return strings.Join(mapOrder, "\n"), nil
+			},
+		},
+		{
+			Name: "map",
+			Args: "<map> <number>",
+			Help: "apply a single map to a number",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 2); errArgs != nil {
+					return "", errArgs
+				}
+
+				num, errNum := parseNumber(args[1])
+				if errNum != nil {
+					return "", errNum
+				}
+
+				mapped, errMapping := getMapping(args[0], intervals, num)
+				if errMapping != nil {
+					return "", errMapping
+				}
+
+				return strconv.FormatUint(mapped, 10), nil
+			},
+		},
+		{
+			Name: "location",
+			Args: "<seed>",
+			Help: "compute the location for a seed",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 1); errArgs != nil {
+					return "", errArgs
+				}
+
+				seed, errSeed := parseNumber(args[0])
+				if errSeed != nil {
+					return "", errSeed
+				}
+
+				location, errLoc := getLocationForSeed(intervals, seed)
+				if errLoc != nil {
+					return "", errLoc
+				}
+
+				return strconv.FormatUint(location, 10), nil
+			},
+		},
+		{
+			Name: "path",
+			Args: "<seed>",
+			Help: "show every mapping hop from a seed to its location",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 1); errArgs != nil {
+					return "", errArgs
+				}
+
+				seed, errSeed := parseNumber(args[0])
+				if errSeed != nil {
+					return "", errSeed
+				}
+
+				hops := []string{fmt.Sprintf("seed %d", seed)}
+				currentNum := seed
+				for _, mapName := range mapOrder {
+					newNum, errMapping := getMapping(mapName, intervals, currentNum)
+					if errMapping != nil {
+						return "", errMapping
+					}
+
+					currentNum = newNum
+					hops = append(hops, fmt.Sprintf("%s %d", mapName[strings.LastIndex(mapName, "-")+1:], currentNum))
+				}
+
+				return strings.Join(hops, " -> "), nil
+			},
+		},
+	}
+}
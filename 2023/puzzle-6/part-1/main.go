@@ -1,12 +1,13 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"log"
-	"os"
 	"strconv"
 	"strings"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
 )
 
 // race struct represents a racing scenario with a given time and record distance.
@@ -43,22 +44,6 @@ func (r *race) calculateDistanceReached(pressTimeMs int) int {
 	return pressTimeMs * travelTimeMs
 }
 
-// readFileLines reads a file and returns its contents as an array of strings.
-func readFileLines(filePath string) ([]string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	return lines, scanner.Err()
-}
-
 // parseRaces takes an array of strings representing the race time and distance, and parses them into a race slice.
 // It splits the input strings to extract time and distance, converts them to integers,
 // and handles any format errors in the input data.
@@ -92,7 +77,7 @@ func parseRaces(lines [2]string) ([]race, error) {
 }
 
 func main() {
-	lines, errRead := readFileLines(inputFile)
+	lines, errRead := input.ReadLines(inputFile)
 	if errRead != nil {
 		log.Fatal(errRead)
 	}
@@ -110,5 +95,5 @@ func main() {
 		}
 	}
 
-	fmt.Println(prod)
+	solution.Print(prod)
 }
@@ -1,12 +1,13 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"log"
-	"os"
 	"strconv"
 	"strings"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
 )
 
 const inputFile = "input.txt"
@@ -43,22 +44,6 @@ func (r *race) calculateDistanceReached(pressTimeMs int) int {
 	return pressTimeMs * travelTimeMs
 }
 
-// readFileLines reads a file and returns its contents as an array of strings.
-func readFileLines(filePath string) ([]string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	return lines, scanner.Err()
-}
-
 // parseRace takes an array of strings representing the race time and distance, and parses them into a race struct.
 // It splits the input strings to extract time and distance, converts them to integers,
 // and handles any format errors in the input data.
@@ -86,7 +71,7 @@ func parseRace(lines [2]string) (race, error) {
 }
 
 func main() {
-	lines, errRead := readFileLines(inputFile)
+	lines, errRead := input.ReadLines(inputFile)
 	if errRead != nil {
 		log.Fatal(errRead)
 	}
@@ -96,5 +81,5 @@ func main() {
 		log.Fatal(errRace)
 	}
 
-	fmt.Println(race.countWaysOfWinning())
+	solution.Print(race.countWaysOfWinning())
 }
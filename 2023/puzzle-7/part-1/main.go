@@ -1,13 +1,16 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"log"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/repl"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
 )
 
 const inputFile = "input.txt"
@@ -121,24 +124,8 @@ func parseHands(lines []string) ([]hand, error) {
 	return hands, nil
 }
 
-// readFileLines reads a file and returns its contents as an array of strings.
-func readFileLines(filePath string) ([]string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	return lines, scanner.Err()
-}
-
 func main() {
-	lines, errRead := readFileLines(inputFile)
+	lines, errRead := input.ReadLines(inputFile)
 	if errRead != nil {
 		log.Fatal(errRead)
 	}
@@ -152,10 +139,17 @@ func main() {
 		return hands[i].Less(hands[j])
 	})
 
+	if repl.Enabled() {
+		if errRepl := repl.Run(os.Stdin, os.Stdout, "day 7 part 1", replCommands(hands)); errRepl != nil {
+			log.Fatal(errRepl)
+		}
+		return
+	}
+
 	winnings := 0
 	for i, hand := range hands {
 		winnings += (i + 1) * hand.bid
 	}
 
-	fmt.Println(winnings)
+	solution.Print(winnings)
 }
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/repl"
+)
+
+// handTypeNames maps each hand type to its name.
+var handTypeNames = map[handType]string{
+	fiveOfAKind:  "five of a kind",
+	fourOfAKind:  "four of a kind",
+	fullHouse:    "full house",
+	threeOfAKind: "three of a kind",
+	twoPair:      "two pair",
+	onePair:      "one pair",
+	highCard:     "high card",
+}
+
+// parseCards parses the 5 cards of a hand typed in the REPL.
+func parseCards(s string) ([5]rune, error) {
+	runes := []rune(s)
+	if len(runes) != 5 {
+		return [5]rune{}, fmt.Errorf("a hand has 5 cards: %s", s)
+	}
+
+	for _, r := range runes {
+		if !strings.ContainsRune(cardOrder, r) {
+			return [5]rune{}, fmt.Errorf("invalid card: %c", r)
+		}
+	}
+
+	return *(*[5]rune)(runes), nil
+}
+
+// replCommands returns the commands of the REPL, evaluated against the parsed hands, sorted from weakest to strongest.
+func replCommands(hands []hand) []repl.Command {
+	return []repl.Command{
+		{
+			Name: "type",
+			Args: "<cards>",
+			Help: "evaluate the type of a hand, jokers playing as the card giving the best type",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 1); errArgs != nil {
+					return "", errArgs
+				}
+
+				cards, errCards := parseCards(args[0])
+				if errCards != nil {
+					return "", errCards
+				}
+
+				h := hand{cards: cards}
+				return handTypeNames[h.getHandTypeWithJoker()], nil
+			},
+		},
+		{
+			Name: "compare",
+			Args: "<cards> <cards>",
+			Help: "tell which of two hands is the strongest",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 2); errArgs != nil {
+					return "", errArgs
+				}
+
+				cards1, errCards1 := parseCards(args[0])
+				if errCards1 != nil {
+					return "", errCards1
+				}
+
+				cards2, errCards2 := parseCards(args[1])
+				if errCards2 != nil {
+					return "", errCards2
+				}
+
+				h1, h2 := hand{cards: cards1}, hand{cards: cards2}
+				switch {
+				case h1.Less(h2):
+					return fmt.Sprintf("%s < %s", args[0], args[1]), nil
+				case h2.Less(h1):
+					return fmt.Sprintf("%s > %s", args[0], args[1]), nil
+				default:
+					return fmt.Sprintf("%s = %s", args[0], args[1]), nil
+				}
+			},
+		},
+		{
+			Name: "rank",
+			Args: "<cards>",
+			Help: "find the rank and bid of a hand of the input",
+			Run: func(args []string) (string, error) {
+				if errArgs := repl.ExpectArgs(args, 1); errArgs != nil {
+					return "", errArgs
+				}
+
+				cards, errCards := parseCards(args[0])
+				if errCards != nil {
+					return "", errCards
+				}
+
+				for i, h := range hands {
+					if h.cards == cards {
+						return fmt.Sprintf("rank %d of %d, bid %d, winning %d", i+1, len(hands), h.bid, (i+1)*h.bid), nil
+					}
+				}
+
+				return "", fmt.Errorf("no such hand in the input: %s", args[0])
+			},
+		},
+	}
+}
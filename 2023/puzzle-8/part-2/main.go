@@ -1,12 +1,14 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"log"
-	"os"
 	"strings"
 	"sync"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/input"
+	"github.com/maaxleq/advent-of-code-2023/lib/numtheory"
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
 )
 
 const inputFile = "input.txt"
@@ -72,22 +74,6 @@ func parseLines(lines []string) (instructions, map[node]crossing, error) {
 	}, network, nil
 }
 
-// readFileLines reads a file and returns its contents as an array of strings.
-func readFileLines(filePath string) ([]string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	return lines, scanner.Err()
-}
-
 // getStartingNodes extracts and returns all nodes from the network map that end with the character 'A'.
 func getStartingNodes(network map[node]crossing) []node {
 	nodes := []node{}
@@ -101,34 +87,8 @@ func getStartingNodes(network map[node]crossing) []node {
 	return nodes
 }
 
-// gcd computes the Greatest Common Divisor using the Euclidean algorithm
-func gcd(a, b uint) uint {
-	for b != 0 {
-		a, b = b, a%b
-	}
-	return a
-}
-
-// lcm computes the Least Common Multiple of two numbers
-func lcm(a, b uint) uint {
-	return a / gcd(a, b) * b
-}
-
-// lcmSlice computes the LCM of a slice of uint
-func lcmSlice(numbers []uint) uint {
-	if len(numbers) == 0 {
-		return 0 // No LCM for empty slice
-	}
-
-	result := numbers[0]
-	for _, number := range numbers[1:] {
-		result = lcm(result, number)
-	}
-	return result
-}
-
 func main() {
-	lines, errRead := readFileLines(inputFile)
+	lines, errRead := input.ReadLines(inputFile)
 	if errRead != nil {
 		log.Fatal(errRead)
 	}
@@ -165,12 +125,15 @@ func main() {
 	wg.Wait()
 	close(steps)
 
-	stepCounts := []uint{}
+	stepCounts := []int64{}
 	for stepCount := range steps {
-		stepCounts = append(stepCounts, stepCount)
+		stepCounts = append(stepCounts, int64(stepCount))
 	}
 
-	result := lcmSlice(stepCounts)
+	result, errLcm := numtheory.LcmSliceChecked(stepCounts)
+	if errLcm != nil {
+		log.Fatal(errLcm)
+	}
 
-	fmt.Println(result)
+	solution.Print(result)
 }
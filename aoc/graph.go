@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// graphPart is the part of a puzzle whose solver exports graphs, as it knows about more of the input than the other one.
+const graphPart = 2
+
+// runGraph implements the graph command, which writes a graph built from the input of a puzzle, like the adjacency
+// of part numbers and symbols of day 3, by running the solver with its -graph flag.
+// Flags may also follow the day, as in: aoc graph 3 -format graphml > schematic.graphml.
+func runGraph(args []string) error {
+	fs := flag.NewFlagSet("graph", flag.ExitOnError)
+	format := fs.String("format", "dot", "format of the graph, among those the solver writes, like dot, graphml or json")
+	part := fs.Int("part", graphPart, "part of the puzzle whose solver exports the graph")
+	year := addYearFlag(fs)
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		return fmt.Errorf("expected <day>, got %d arguments", fs.NArg())
+	}
+	day := fs.Arg(0)
+
+	fs.Parse(fs.Args()[1:])
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments after the day: %v", fs.Args())
+	}
+
+	_, s, errSolver := findSolver(*year, []string{day, strconv.Itoa(*part)})
+	if errSolver != nil {
+		return errSolver
+	}
+
+	tmpDir, errTmp := os.MkdirTemp("", "aoc-")
+	if errTmp != nil {
+		return errTmp
+	}
+	defer os.RemoveAll(tmpDir)
+
+	bin, errBuild := s.build(tmpDir)
+	if errBuild != nil {
+		return errBuild
+	}
+
+	graphs, errFlag := s.acceptsFlag(bin, "graph")
+	if errFlag != nil {
+		return errFlag
+	}
+	if !graphs {
+		return fmt.Errorf("%s does not export graphs", s)
+	}
+
+	if errRun := s.runAttached(bin, []string{"-graph", *format}); errRun != nil {
+		return fmt.Errorf("%s failed: %w", s, errRun)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// guessLogFile is the path, relative to the repository root, of the log of submitted answers.
+var guessLogFile = filepath.Join(".aoc", "guesses.json")
+
+// guess is an answer that was submitted to the server, along with the server's verdict.
+type guess struct {
+	Year    int       `json:"year"`
+	Day     int       `json:"day"`
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Outcome outcome   `json:"outcome"`
+	Time    time.Time `json:"time"`
+}
+
+// guessLog is the local history of submitted answers, persisted as JSON.
+type guessLog struct {
+	path    string
+	guesses []guess
+}
+
+// loadGuessLog reads the guess log at the given path. A missing file is an empty log.
+func loadGuessLog(path string) (*guessLog, error) {
+	gl := &guessLog{path: path}
+
+	data, errRead := os.ReadFile(path)
+	if errors.Is(errRead, os.ErrNotExist) {
+		return gl, nil
+	}
+	if errRead != nil {
+		return nil, errRead
+	}
+
+	if errJSON := json.Unmarshal(data, &gl.guesses); errJSON != nil {
+		return nil, fmt.Errorf("cannot read guess log %s: %w", path, errJSON)
+	}
+
+	return gl, nil
+}
+
+// add records a new guess and writes the log back to disk.
+func (gl *guessLog) add(g guess) error {
+	gl.guesses = append(gl.guesses, g)
+
+	data, errJSON := json.MarshalIndent(gl.guesses, "", "  ")
+	if errJSON != nil {
+		return errJSON
+	}
+
+	if errMkdir := os.MkdirAll(filepath.Dir(gl.path), 0o755); errMkdir != nil {
+		return errMkdir
+	}
+
+	return os.WriteFile(gl.path, append(data, '\n'), 0o644)
+}
+
+// check tells whether submitting answer for the given puzzle part would be pointless.
+// It returns an error explaining why if the part was already solved, if the very same answer
+// was already rejected, or if a previous too high or too low verdict rules the answer out.
+func (gl *guessLog) check(key puzzleKey, answer string) error {
+	num, errNum := strconv.ParseInt(answer, 10, 64)
+	isNum := errNum == nil
+
+	for _, g := range gl.guesses {
+		if (puzzleKey{year: g.Year, day: g.Day, part: g.Part}) != key {
+			continue
+		}
+
+		if g.Outcome == outcomeCorrect {
+			return fmt.Errorf("%s is already solved with answer %s", key, g.Answer)
+		}
+
+		if g.Answer == answer && g.Outcome.isWrong() {
+			return fmt.Errorf("answer %s was already submitted on %s and was %s", answer, g.Time.Format(time.DateTime), g.Outcome)
+		}
+
+		guessNum, errGuessNum := strconv.ParseInt(g.Answer, 10, 64)
+		if !isNum || errGuessNum != nil {
+			continue
+		}
+
+		if g.Outcome == outcomeTooHigh && num >= guessNum {
+			return fmt.Errorf("answer %s cannot be right: %s was already too high", answer, g.Answer)
+		}
+
+		if g.Outcome == outcomeTooLow && num <= guessNum {
+			return fmt.Errorf("answer %s cannot be right: %s was already too low", answer, g.Answer)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// unlockHourUTC is the hour, in UTC, at which puzzles unlock (midnight in the UTC-5 timezone).
+const unlockHourUTC = 5
+
+// leaderboard is a private leaderboard, as exported in JSON by the Advent of Code server.
+type leaderboard struct {
+	Event   string            `json:"event"`
+	OwnerID int               `json:"owner_id"`
+	Members map[string]member `json:"members"`
+}
+
+// member is a participant of a private leaderboard.
+type member struct {
+	ID                 int                                  `json:"id"`
+	Name               *string                              `json:"name"`
+	LocalScore         int                                  `json:"local_score"`
+	Stars              int                                  `json:"stars"`
+	LastStarTs         int64                                `json:"last_star_ts"`
+	CompletionDayLevel map[string]map[string]starCompletion `json:"completion_day_level"`
+}
+
+// starCompletion records when a member got a star.
+type starCompletion struct {
+	GetStarTs int64 `json:"get_star_ts"`
+	StarIndex int64 `json:"star_index"`
+}
+
+// star is a star obtained by a member, with the puzzle part it was obtained for.
+type star struct {
+	day, part int
+	at        time.Time
+}
+
+// displayName returns the name of the member, or a placeholder for anonymous members.
+func (m member) displayName() string {
+	if m.Name == nil || *m.Name == "" {
+		return fmt.Sprintf("(anonymous user #%d)", m.ID)
+	}
+
+	return *m.Name
+}
+
+// stars returns every star of the member in chronological order.
+func (m member) stars() ([]star, error) {
+	stars := []star{}
+
+	for dayStr, parts := range m.CompletionDayLevel {
+		day, errDay := strconv.Atoi(dayStr)
+		if errDay != nil {
+			return nil, fmt.Errorf("invalid day for member %d: %s", m.ID, dayStr)
+		}
+
+		for partStr, completion := range parts {
+			part, errPart := strconv.Atoi(partStr)
+			if errPart != nil {
+				return nil, fmt.Errorf("invalid part for member %d: %s", m.ID, partStr)
+			}
+
+			stars = append(stars, star{
+				day:  day,
+				part: part,
+				at:   time.Unix(completion.GetStarTs, 0).UTC(),
+			})
+		}
+	}
+
+	sort.Slice(stars, func(i, j int) bool {
+		return stars[i].at.Before(stars[j].at)
+	})
+
+	return stars, nil
+}
+
+// unlockTime returns the moment the puzzle of the given day of the event was released.
+func (lb *leaderboard) unlockTime(day int) (time.Time, error) {
+	eventYear, errYear := strconv.Atoi(lb.Event)
+	if errYear != nil {
+		return time.Time{}, fmt.Errorf("invalid event: %s", lb.Event)
+	}
+
+	return time.Date(eventYear, time.December, day, unlockHourUTC, 0, 0, 0, time.UTC), nil
+}
+
+// sortedMembers returns the members of the leaderboard ordered by id, so outputs are stable.
+func (lb *leaderboard) sortedMembers() []member {
+	members := []member{}
+	for _, m := range lb.Members {
+		members = append(members, m)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].ID < members[j].ID
+	})
+
+	return members
+}
+
+// medianSolveTime returns the median time the member took to get their stars, counted from
+// the unlock of each puzzle. It returns false if the member has no star.
+func (lb *leaderboard) medianSolveTime(m member) (time.Duration, bool, error) {
+	stars, errStars := m.stars()
+	if errStars != nil {
+		return 0, false, errStars
+	}
+
+	if len(stars) == 0 {
+		return 0, false, nil
+	}
+
+	durations := []time.Duration{}
+	for _, s := range stars {
+		unlock, errUnlock := lb.unlockTime(s.day)
+		if errUnlock != nil {
+			return 0, false, errUnlock
+		}
+		durations = append(durations, s.at.Sub(unlock))
+	}
+
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+
+	mid := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[mid-1] + durations[mid]) / 2, true, nil
+	}
+
+	return durations[mid], true, nil
+}
+
+// table is tabular output, rendered either as aligned text or as CSV.
+type table struct {
+	format string
+	header []string
+	rows   [][]string
+}
+
+// duration formats a duration for the output format: seconds for CSV, readable text otherwise.
+func (t *table) duration(d time.Duration) string {
+	if t.format == "csv" {
+		return strconv.FormatInt(int64(d/time.Second), 10)
+	}
+
+	return d.Round(time.Second).String()
+}
+
+// write renders the table to w.
+func (t *table) write(w io.Writer) error {
+	if t.format == "csv" {
+		cw := csv.NewWriter(w)
+		cw.Write(t.header)
+		cw.WriteAll(t.rows)
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// ranking is a member with the scores used by every ranking scheme.
+type ranking struct {
+	m        member
+	median   time.Duration
+	hasStars bool
+}
+
+// rankingTable ranks the members of the leaderboard according to the given scheme:
+// "local" for the local score, "stars" for the number of stars, or "median" for the median solve time.
+// Ties are broken by whoever got their last star first.
+func (lb *leaderboard) rankingTable(scheme, format string) (*table, error) {
+	rankings := []ranking{}
+	for _, m := range lb.sortedMembers() {
+		median, hasStars, errMedian := lb.medianSolveTime(m)
+		if errMedian != nil {
+			return nil, errMedian
+		}
+
+		rankings = append(rankings, ranking{
+			m:        m,
+			median:   median,
+			hasStars: hasStars,
+		})
+	}
+
+	var less func(a, b ranking) bool
+	switch scheme {
+	case "local":
+		less = func(a, b ranking) bool {
+			return a.m.LocalScore > b.m.LocalScore
+		}
+	case "stars":
+		less = func(a, b ranking) bool {
+			return a.m.Stars > b.m.Stars
+		}
+	case "median":
+		less = func(a, b ranking) bool {
+			if a.hasStars != b.hasStars {
+				return a.hasStars
+			}
+			return a.median < b.median
+		}
+	default:
+		return nil, fmt.Errorf("invalid ranking scheme: %s", scheme)
+	}
+
+	sort.SliceStable(rankings, func(i, j int) bool {
+		if less(rankings[i], rankings[j]) {
+			return true
+		}
+		if less(rankings[j], rankings[i]) {
+			return false
+		}
+		return rankings[i].m.LastStarTs < rankings[j].m.LastStarTs
+	})
+
+	t := &table{
+		format: format,
+		header: []string{"rank", "name", "local score", "stars", "median solve time"},
+	}
+	for i, r := range rankings {
+		median := "-"
+		if r.hasStars {
+			median = t.duration(r.median)
+		}
+
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(i + 1),
+			r.m.displayName(),
+			strconv.Itoa(r.m.LocalScore),
+			strconv.Itoa(r.m.Stars),
+			median,
+		})
+	}
+
+	return t, nil
+}
+
+// deltasTable lists, for every member and day, how long part 1 took from the unlock
+// and how long part 2 took after part 1.
+func (lb *leaderboard) deltasTable(format string) (*table, error) {
+	type delta struct {
+		name     string
+		day      int
+		part1    time.Duration
+		part2    time.Duration
+		hasPart2 bool
+	}
+
+	deltas := []delta{}
+	for _, m := range lb.sortedMembers() {
+		for dayStr, parts := range m.CompletionDayLevel {
+			day, errDay := strconv.Atoi(dayStr)
+			if errDay != nil {
+				return nil, fmt.Errorf("invalid day for member %d: %s", m.ID, dayStr)
+			}
+
+			first, hasFirst := parts["1"]
+			if !hasFirst {
+				continue
+			}
+
+			unlock, errUnlock := lb.unlockTime(day)
+			if errUnlock != nil {
+				return nil, errUnlock
+			}
+
+			d := delta{
+				name:  m.displayName(),
+				day:   day,
+				part1: time.Unix(first.GetStarTs, 0).Sub(unlock),
+			}
+			if second, hasSecond := parts["2"]; hasSecond {
+				d.part2 = time.Duration(second.GetStarTs-first.GetStarTs) * time.Second
+				d.hasPart2 = true
+			}
+
+			deltas = append(deltas, d)
+		}
+	}
+
+	sort.SliceStable(deltas, func(i, j int) bool {
+		if deltas[i].day != deltas[j].day {
+			return deltas[i].day < deltas[j].day
+		}
+		if deltas[i].hasPart2 != deltas[j].hasPart2 {
+			return deltas[i].hasPart2
+		}
+		return deltas[i].part2 < deltas[j].part2
+	})
+
+	t := &table{
+		format: format,
+		header: []string{"day", "name", "part 1 time", "part 2 delta"},
+	}
+	for _, d := range deltas {
+		part2 := "-"
+		if d.hasPart2 {
+			part2 = t.duration(d.part2)
+		}
+
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(d.day),
+			d.name,
+			t.duration(d.part1),
+			part2,
+		})
+	}
+
+	return t, nil
+}
+
+// timelineTable lists every star of the leaderboard in chronological order,
+// along with the number of stars the member had at that moment.
+func (lb *leaderboard) timelineTable(format string) (*table, error) {
+	type event struct {
+		name  string
+		s     star
+		total int
+	}
+
+	events := []event{}
+	for _, m := range lb.sortedMembers() {
+		stars, errStars := m.stars()
+		if errStars != nil {
+			return nil, errStars
+		}
+
+		for i, s := range stars {
+			events = append(events, event{
+				name:  m.displayName(),
+				s:     s,
+				total: i + 1,
+			})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].s.at.Before(events[j].s.at)
+	})
+
+	t := &table{
+		format: format,
+		header: []string{"time", "name", "day", "part", "stars"},
+	}
+	for _, e := range events {
+		t.rows = append(t.rows, []string{
+			e.s.at.Format(time.RFC3339),
+			e.name,
+			strconv.Itoa(e.s.day),
+			strconv.Itoa(e.s.part),
+			strconv.Itoa(e.total),
+		})
+	}
+
+	return t, nil
+}
+
+// runLeaderboard implements the leaderboard command, which analyzes an exported private leaderboard.
+func runLeaderboard(args []string) error {
+	fs := flag.NewFlagSet("leaderboard", flag.ExitOnError)
+	view := fs.String("view", "ranking", "what to compute: ranking, deltas or timeline")
+	scheme := fs.String("scheme", "local", "ranking scheme: local, stars or median")
+	format := fs.String("format", "text", "output format: text or csv")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expected the path of the exported leaderboard")
+	}
+
+	if *format != "text" && *format != "csv" {
+		return fmt.Errorf("invalid format: %s", *format)
+	}
+
+	data, errRead := os.ReadFile(fs.Arg(0))
+	if errRead != nil {
+		return errRead
+	}
+
+	lb := &leaderboard{}
+	if errJSON := json.Unmarshal(data, lb); errJSON != nil {
+		return fmt.Errorf("cannot parse leaderboard: %w", errJSON)
+	}
+
+	var t *table
+	var errTable error
+	switch *view {
+	case "ranking":
+		t, errTable = lb.rankingTable(*scheme, *format)
+	case "deltas":
+		t, errTable = lb.deltasTable(*format)
+	case "timeline":
+		t, errTable = lb.timelineTable(*format)
+	default:
+		return fmt.Errorf("invalid view: %s", *view)
+	}
+	if errTable != nil {
+		return errTable
+	}
+
+	return t.write(os.Stdout)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+)
+
+// command is a subcommand of the aoc tool.
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+// commands maps each subcommand name to its implementation.
+var commands = map[string]command{
+	"graph": {
+		usage: "graph [flags] <day> [flags]",
+		run:   runGraph,
+	},
+	"leaderboard": {
+		usage: "leaderboard [flags] <file>",
+		run:   runLeaderboard,
+	},
+	"render": {
+		usage: "render [flags] <day> <part>",
+		run:   runRender,
+	},
+	"query": {
+		usage: "query [flags] <day> <expression> [flags]",
+		run:   runQuery,
+	},
+	"repl": {
+		usage: "repl [flags] <day> <part>",
+		run:   runRepl,
+	},
+	"report": {
+		usage: "report [flags]",
+		run:   runReport,
+	},
+	"run": {
+		usage: "run [flags] <day> <part> [-- solver flags]",
+		run:   runRun,
+	},
+	"submit": {
+		usage: "submit [flags] <day> <part>",
+		run:   runSubmit,
+	},
+	"watch": {
+		usage: "watch [flags] <day> <part>",
+		run:   runWatch,
+	},
+}
+
+// printUsage writes the list of available subcommands to stderr.
+func printUsage() {
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage: aoc <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  aoc %s\n", commands[name].usage)
+	}
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("aoc: ")
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	cmd, exists := commands[os.Args[1]]
+	if !exists {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", os.Args[1])
+		printUsage()
+		os.Exit(2)
+	}
+
+	if errRun := cmd.run(os.Args[2:]); errRun != nil {
+		log.Fatal(errRun)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// queryPart is the part of a puzzle whose solver answers queries, as it parses the same input as the other one.
+const queryPart = 1
+
+// runQuery implements the query command, which filters the data of a puzzle with an expression
+// and computes aggregates over it, by running the solver with its -query and -agg flags.
+// Flags may also follow the positional arguments, as in: aoc query 2 'max(red) > 10' -agg 'sum(id)'.
+func runQuery(args []string) error {
+	fs := flag.NewFlagSet("query", flag.ExitOnError)
+	agg := fs.String("agg", "", "comma separated aggregates to compute over the matching data, like \"sum(id), count()\"")
+	part := fs.Int("part", queryPart, "part of the puzzle whose solver answers the query")
+	year := addYearFlag(fs)
+	fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		return fmt.Errorf("expected <day> <expression>, got %d arguments", fs.NArg())
+	}
+	day, expression := fs.Arg(0), fs.Arg(1)
+
+	fs.Parse(fs.Args()[2:])
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments after the expression: %v", fs.Args())
+	}
+
+	_, s, errSolver := findSolver(*year, []string{day, strconv.Itoa(*part)})
+	if errSolver != nil {
+		return errSolver
+	}
+
+	tmpDir, errTmp := os.MkdirTemp("", "aoc-")
+	if errTmp != nil {
+		return errTmp
+	}
+	defer os.RemoveAll(tmpDir)
+
+	bin, errBuild := s.build(tmpDir)
+	if errBuild != nil {
+		return errBuild
+	}
+
+	queries, errFlag := s.acceptsFlag(bin, "query")
+	if errFlag != nil {
+		return errFlag
+	}
+	if !queries {
+		return fmt.Errorf("%s does not answer queries", s)
+	}
+
+	solverArgs := []string{"-query", expression}
+	if *agg != "" {
+		solverArgs = append(solverArgs, "-agg", *agg)
+	}
+
+	if errRun := s.runAttached(bin, solverArgs); errRun != nil {
+		return fmt.Errorf("%s failed: %w", s, errRun)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// inputFileName is the name of the file holding the puzzle input, in the directory of each solver.
+const inputFileName = "input.txt"
+
+// puzzleKey identifies one part of one puzzle of one Advent of Code event.
+type puzzleKey struct {
+	year, day, part int
+}
+
+// String returns a short human readable name of the puzzle part.
+func (k puzzleKey) String() string {
+	return fmt.Sprintf("%d day %d part %d", k.year, k.day, k.part)
+}
+
+// less orders puzzle parts chronologically.
+func (k puzzleKey) less(other puzzleKey) bool {
+	if k.year != other.year {
+		return k.year < other.year
+	}
+	if k.day != other.day {
+		return k.day < other.day
+	}
+	return k.part < other.part
+}
+
+// dir returns the directory holding the solver and the input of the puzzle part, relative to the repository root.
+// Solutions are laid out as <year>/puzzle-<day>/part-<part>.
+func (k puzzleKey) dir() string {
+	return filepath.Join(fmt.Sprint(k.year), fmt.Sprintf("puzzle-%d", k.day), fmt.Sprintf("part-%d", k.part))
+}
+
+// registry lists every solver of the repository, keyed by the puzzle part it solves.
+type registry map[puzzleKey]solver
+
+// loadRegistry finds the solvers of every year in the repository.
+func loadRegistry(root string) (registry, error) {
+	mains, errGlob := filepath.Glob(filepath.Join(root, "*", "puzzle-*", "part-*", "main.go"))
+	if errGlob != nil {
+		return nil, errGlob
+	}
+
+	reg := make(registry)
+	for _, main := range mains {
+		rel, errRel := filepath.Rel(root, filepath.Dir(main))
+		if errRel != nil {
+			return nil, errRel
+		}
+
+		var key puzzleKey
+		if _, errScan := fmt.Sscanf(filepath.ToSlash(rel), "%d/puzzle-%d/part-%d", &key.year, &key.day, &key.part); errScan != nil {
+			continue // Not a solver directory.
+		}
+
+		if key.dir() != rel {
+			continue // Something like puzzle-01, which would collide with puzzle-1.
+		}
+
+		reg[key] = solver{
+			key: key,
+			dir: filepath.Join(root, rel),
+		}
+	}
+
+	return reg, nil
+}
+
+// sorted returns the solvers of the registry in chronological order.
+func (reg registry) sorted() []solver {
+	solvers := []solver{}
+	for _, s := range reg {
+		solvers = append(solvers, s)
+	}
+
+	sort.Slice(solvers, func(i, j int) bool {
+		return solvers[i].key.less(solvers[j].key)
+	})
+
+	return solvers
+}
+
+// latestYear returns the most recent year with at least one solver, or 0 if the registry is empty.
+func (reg registry) latestYear() int {
+	latest := 0
+	for key := range reg {
+		latest = max(latest, key.year)
+	}
+
+	return latest
+}
+
+// lookup returns the solver of a puzzle part. It returns an error if that part has not been solved yet.
+func (reg registry) lookup(key puzzleKey) (solver, error) {
+	s, exists := reg[key]
+	if !exists {
+		return solver{}, fmt.Errorf("no solver for %s", key)
+	}
+
+	return s, nil
+}
+
+// addYearFlag registers the -year flag of the commands working on a single puzzle part.
+func addYearFlag(fs *flag.FlagSet) *int {
+	return fs.Int("year", 0, "year of the event, the latest year with solutions by default")
+}
+
+// findSolver locates the repository root and the solver of the puzzle part given as <day> <part> arguments.
+// A year of 0 stands for the latest year with solutions.
+func findSolver(year int, args []string) (string, solver, error) {
+	day, part, errArgs := parseDayPart(args)
+	if errArgs != nil {
+		return "", solver{}, errArgs
+	}
+
+	root, errRoot := findRoot()
+	if errRoot != nil {
+		return "", solver{}, errRoot
+	}
+
+	reg, errReg := loadRegistry(root)
+	if errReg != nil {
+		return "", solver{}, errReg
+	}
+
+	if year == 0 {
+		year = reg.latestYear()
+	}
+
+	s, errLookup := reg.lookup(puzzleKey{year: year, day: day, part: part})
+	if errLookup != nil {
+		return "", solver{}, errLookup
+	}
+
+	return root, s, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/render"
+)
+
+// renderPackage is the import path of the package solvers use to render images.
+const renderPackage = "github.com/maaxleq/advent-of-code-2023/lib/render"
+
+// defaultRenderDir is the path, relative to the repository root, of the directory images are written to by default.
+var defaultRenderDir = filepath.Join(".aoc", "renders")
+
+// dirSnapshot stamps every file of a directory. A missing directory has an empty snapshot.
+func dirSnapshot(dir string) (snapshot, error) {
+	entries, errRead := os.ReadDir(dir)
+	if errRead != nil && !errors.Is(errRead, os.ErrNotExist) {
+		return nil, errRead
+	}
+
+	snap := make(snapshot)
+	for _, entry := range entries {
+		info, errInfo := entry.Info()
+		if errInfo != nil {
+			return nil, errInfo
+		}
+
+		snap[filepath.Join(dir, entry.Name())] = fileStamp{
+			modTime: info.ModTime(),
+			size:    info.Size(),
+		}
+	}
+
+	return snap, nil
+}
+
+// runRender implements the render command, which runs a solver with rendering enabled and lists the images it wrote.
+func runRender(args []string) error {
+	fs := flag.NewFlagSet("render", flag.ExitOnError)
+	output := fs.String("o", "", "directory to write the images to, "+defaultRenderDir+" in the repository by default")
+	year := addYearFlag(fs)
+	fs.Parse(args)
+
+	root, s, errSolver := findSolver(*year, fs.Args())
+	if errSolver != nil {
+		return errSolver
+	}
+
+	renders, errUses := s.uses(renderPackage)
+	if errUses != nil {
+		return errUses
+	}
+	if !renders {
+		return fmt.Errorf("%s renders no image", s)
+	}
+
+	dir := filepath.Join(root, defaultRenderDir)
+	if *output != "" {
+		absDir, errAbs := filepath.Abs(*output) // The solver runs from its own directory.
+		if errAbs != nil {
+			return errAbs
+		}
+		dir = absDir
+	}
+
+	before, errBefore := dirSnapshot(dir)
+	if errBefore != nil {
+		return errBefore
+	}
+
+	tmpDir, errTmp := os.MkdirTemp("", "aoc-")
+	if errTmp != nil {
+		return errTmp
+	}
+	defer os.RemoveAll(tmpDir)
+
+	bin, errBuild := s.build(tmpDir)
+	if errBuild != nil {
+		return errBuild
+	}
+
+	answer, elapsed, errRun := s.run(context.Background(), bin, render.EnvVar+"="+dir)
+	if errRun != nil {
+		return errRun
+	}
+	fmt.Printf("%s: %s (%s)\n", s, answer, elapsed.Round(time.Millisecond))
+
+	after, errAfter := dirSnapshot(dir)
+	if errAfter != nil {
+		return errAfter
+	}
+
+	for _, file := range after.changedFiles(before) {
+		if _, exists := after[file]; exists {
+			fmt.Printf("rendered %s\n", file)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/repl"
+)
+
+// replPackage is the import path of the package solvers use to provide a REPL.
+const replPackage = "github.com/maaxleq/advent-of-code-2023/lib/repl"
+
+// runRepl implements the repl command, which starts the interactive REPL of a solver on its parsed input.
+func runRepl(args []string) error {
+	fs := flag.NewFlagSet("repl", flag.ExitOnError)
+	year := addYearFlag(fs)
+	fs.Parse(args)
+
+	_, s, errSolver := findSolver(*year, fs.Args())
+	if errSolver != nil {
+		return errSolver
+	}
+
+	hasRepl, errUses := s.uses(replPackage)
+	if errUses != nil {
+		return errUses
+	}
+	if !hasRepl {
+		return fmt.Errorf("%s has no REPL", s)
+	}
+
+	tmpDir, errTmp := os.MkdirTemp("", "aoc-")
+	if errTmp != nil {
+		return errTmp
+	}
+	defer os.RemoveAll(tmpDir)
+
+	bin, errBuild := s.build(tmpDir)
+	if errBuild != nil {
+		return errBuild
+	}
+
+	return s.runAttached(bin, nil, repl.EnvVar+"=1")
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/solution"
+)
+
+// reportEntry is the outcome of running one solver for the report.
+type reportEntry struct {
+	key      puzzleKey
+	answer   string
+	stats    solution.Stats
+	hasStats bool // False for solvers which do not report statistics, whose duration is then the wall time.
+	err      error
+	timedOut bool
+}
+
+// overBudget returns true if the solver did not find its answer within the time budget.
+func (e reportEntry) overBudget(budget time.Duration) bool {
+	return e.timedOut || (e.err == nil && e.stats.Duration > budget)
+}
+
+// runForReport builds and runs a solver, collecting the statistics it writes.
+// Failures of the solver are recorded in the entry rather than returned.
+func runForReport(s solver, tmpDir string, timeout time.Duration) (reportEntry, error) {
+	entry := reportEntry{key: s.key}
+
+	bin, errBuild := s.build(tmpDir)
+	if errBuild != nil {
+		entry.err = errBuild
+		return entry, nil
+	}
+
+	statsPath := filepath.Join(tmpDir, fmt.Sprintf("%d-%d-%d-stats.json", s.key.year, s.key.day, s.key.part))
+	os.Remove(statsPath)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	answer, elapsed, errRun := s.run(ctx, bin, solution.StatsEnvVar+"="+statsPath)
+	if errRun != nil {
+		entry.err = errRun
+		entry.timedOut = errors.Is(errRun, context.DeadlineExceeded)
+		return entry, nil
+	}
+	entry.answer = answer
+	entry.stats.Duration = elapsed
+
+	data, errRead := os.ReadFile(statsPath)
+	if errors.Is(errRead, os.ErrNotExist) {
+		return entry, nil
+	}
+	if errRead != nil {
+		return entry, errRead
+	}
+
+	if errJSON := json.Unmarshal(data, &entry.stats); errJSON != nil {
+		return entry, fmt.Errorf("invalid statistics from %s: %w", s, errJSON)
+	}
+	entry.hasStats = true
+
+	return entry, nil
+}
+
+// goVersion returns the version of the Go toolchain solvers are built with.
+func goVersion() string {
+	output, errGo := exec.Command("go", "env", "GOVERSION").Output()
+	if errGo != nil {
+		return runtime.Version()
+	}
+
+	return strings.TrimSpace(string(output))
+}
+
+// cpuModel returns the model name of the processor, or the architecture if it cannot be found.
+func cpuModel() string {
+	switch runtime.GOOS {
+	case "linux":
+		file, errOpen := os.Open("/proc/cpuinfo")
+		if errOpen != nil {
+			break
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			if name, value, found := strings.Cut(scanner.Text(), ":"); found && strings.TrimSpace(name) == "model name" {
+				return strings.TrimSpace(value)
+			}
+		}
+	case "darwin":
+		output, errSysctl := exec.Command("sysctl", "-n", "machdep.cpu.brand_string").Output()
+		if errSysctl == nil {
+			return strings.TrimSpace(string(output))
+		}
+	}
+
+	return runtime.GOARCH
+}
+
+// formatCount formats a number with thousands separators.
+func formatCount(n uint64) string {
+	s := strconv.FormatUint(n, 10)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+
+	return s
+}
+
+// writeReport renders the entries as a Markdown document.
+func writeReport(w io.Writer, entries []reportEntry, budget, timeout time.Duration) {
+	fmt.Fprintln(w, "# Advent of Code run report")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Generated on %s.\n", time.Now().UTC().Format("2006-01-02 15:04 MST"))
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "## Environment")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| | |")
+	fmt.Fprintln(w, "|---|---|")
+	fmt.Fprintf(w, "| Go version | %s |\n", goVersion())
+	fmt.Fprintf(w, "| OS / architecture | %s/%s |\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(w, "| CPU | %s (%d threads) |\n", cpuModel(), runtime.NumCPU())
+	fmt.Fprintf(w, "| Time budget | %s |\n", budget)
+	fmt.Fprintf(w, "| Timeout | %s |\n", timeout)
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "## Results")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Year | Day | Part | Answer | Time | Allocations | Over budget |")
+	fmt.Fprintln(w, "|---:|---:|---:|---:|---:|---:|:---:|")
+	for _, e := range entries {
+		answer, duration, allocs := "", "", "-"
+		switch {
+		case e.timedOut:
+			answer, duration = "timed out", "> "+timeout.String()
+		case e.err != nil:
+			answer = "failed"
+		default:
+			answer = "`" + e.answer + "`"
+			duration = e.stats.Duration.Round(time.Microsecond).String()
+			if e.hasStats {
+				allocs = formatCount(e.stats.Allocs)
+			}
+		}
+
+		over := ""
+		if e.overBudget(budget) {
+			over = "yes"
+		}
+
+		fmt.Fprintf(w, "| %d | %d | %d | %s | %s | %s | %s |\n", e.key.year, e.key.day, e.key.part, answer, duration, allocs, over)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "## Over budget")
+	fmt.Fprintln(w)
+	overCount := 0
+	for _, e := range entries {
+		if !e.overBudget(budget) {
+			continue
+		}
+
+		overCount++
+		if e.timedOut {
+			fmt.Fprintf(w, "- %s: timed out after %s\n", e.key, timeout)
+		} else {
+			fmt.Fprintf(w, "- %s: %s\n", e.key, e.stats.Duration.Round(time.Millisecond))
+		}
+	}
+	if overCount == 0 {
+		fmt.Fprintf(w, "Every solver finished within %s.\n", budget)
+	}
+
+	failed := []reportEntry{}
+	for _, e := range entries {
+		if e.err != nil && !e.timedOut {
+			failed = append(failed, e)
+		}
+	}
+	if len(failed) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "## Failures")
+		fmt.Fprintln(w)
+		for _, e := range failed {
+			fmt.Fprintf(w, "- %s: %s\n", e.key, strings.SplitN(e.err.Error(), "\n", 2)[0])
+		}
+	}
+}
+
+// runReport implements the report command, which runs every solver and writes a Markdown report of the run.
+func runReport(args []string) error {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	year := fs.Int("year", 0, "only run the solvers of this year, every year by default")
+	budget := fs.Duration("budget", time.Second, "time above which a solver is reported as over budget")
+	timeout := fs.Duration("timeout", time.Minute, "time after which a solver is stopped")
+	output := fs.String("o", "", "file to write the report to, standard output by default")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	root, errRoot := findRoot()
+	if errRoot != nil {
+		return errRoot
+	}
+
+	reg, errReg := loadRegistry(root)
+	if errReg != nil {
+		return errReg
+	}
+
+	tmpDir, errTmp := os.MkdirTemp("", "aoc-report-")
+	if errTmp != nil {
+		return errTmp
+	}
+	defer os.RemoveAll(tmpDir)
+
+	entries := []reportEntry{}
+	for _, s := range reg.sorted() {
+		if *year != 0 && s.key.year != *year {
+			continue
+		}
+
+		fmt.Fprintf(os.Stderr, "running %s\n", s)
+		entry, errEntry := runForReport(s, tmpDir, *timeout)
+		if errEntry != nil {
+			return errEntry
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return fmt.Errorf("no solver to run")
+	}
+
+	out := io.Writer(os.Stdout)
+	if *output != "" {
+		file, errCreate := os.Create(*output)
+		if errCreate != nil {
+			return errCreate
+		}
+		defer file.Close()
+		out = file
+	}
+
+	writeReport(out, entries, *budget, *timeout)
+	return nil
+}
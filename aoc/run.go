@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/term"
+	"github.com/maaxleq/advent-of-code-2023/lib/trace"
+)
+
+// termPackage is the import path of the package solvers use to animate their simulations in the terminal.
+const termPackage = "github.com/maaxleq/advent-of-code-2023/lib/term"
+
+// explainFormats maps the name of each trace event emitted by the solvers to the way -explain renders it.
+// Fields of the event are substituted for their {name}. Events missing from this map are rendered as
+// their sorted fields.
+var explainFormats = map[string]string{
+	"token":        "found digit {value} as {token} at position {pos}",
+	"calibration":  "line {line} has calibration value {value}",
+	"hop":          "seed {seed}: {map} maps {from} to {to}",
+	"pipe":         "pipe {tile} at ({x}, {y}), heading ({dx}, {dy})",
+	"arrangements": "{condition}: {count} arrangements, {hits} cache hits and {misses} misses",
+	"tilt":         "tilted {direction}, load is now {load}",
+	"cycle":        "state after {end} cycles already seen after {start}",
+	"split":        "beam split by {tile} at ({x}, {y}) into {into}",
+}
+
+// placeholderRegexp matches the field placeholders of an explain format.
+var placeholderRegexp = regexp.MustCompile(`\{(\w+)\}`)
+
+// explainEvent renders a trace event as a line of readable text.
+func explainEvent(event map[string]any) string {
+	name := fmt.Sprint(event["event"])
+
+	if format, exists := explainFormats[name]; exists {
+		return placeholderRegexp.ReplaceAllStringFunc(format, func(placeholder string) string {
+			value, found := event[placeholder[1:len(placeholder)-1]]
+			if !found {
+				return placeholder
+			}
+			return fmt.Sprint(value)
+		})
+	}
+
+	keys := []string{}
+	for key := range event {
+		if key != "event" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	fields := []string{name}
+	for _, key := range keys {
+		fields = append(fields, fmt.Sprintf("%s=%v", key, event[key]))
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// explainTrace reads a trace file written by a solver and renders each of its events to w.
+func explainTrace(w io.Writer, path string) error {
+	file, errOpen := os.Open(path)
+	if errors.Is(errOpen, os.ErrNotExist) {
+		fmt.Fprintln(w, "the solver recorded no event")
+		return nil
+	}
+	if errOpen != nil {
+		return errOpen
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		decoder := json.NewDecoder(strings.NewReader(scanner.Text()))
+		decoder.UseNumber() // Keep large numbers exact instead of printing them as floats.
+
+		var event map[string]any
+		if errJSON := decoder.Decode(&event); errJSON != nil {
+			return fmt.Errorf("invalid trace event %q: %w", scanner.Text(), errJSON)
+		}
+
+		fmt.Fprintln(w, explainEvent(event))
+	}
+
+	return scanner.Err()
+}
+
+// runRun implements the run command, which runs a solver, optionally recording or explaining the events it traces.
+// Arguments after "--" are passed to the solver, which runs from its own directory.
+func runRun(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	traceFile := fs.String("trace", "", "file to write the trace events of the solver to as JSON lines, - for standard error")
+	explain := fs.Bool("explain", false, "print the trace events of the solver as readable text after its answer")
+	animate := fs.Bool("animate", false, "animate the simulation of the solver in the terminal")
+	fps := fs.Int("fps", 20, "frames per second of the animation")
+	year := addYearFlag(fs)
+	fs.Parse(args)
+
+	dayPart, solverArgs := splitSolverArgs(fs.Args())
+	_, s, errSolver := findSolver(*year, dayPart)
+	if errSolver != nil {
+		return errSolver
+	}
+
+	if *animate {
+		if *fps <= 0 {
+			return fmt.Errorf("invalid frame rate %d", *fps)
+		}
+
+		animates, errUses := s.uses(termPackage)
+		if errUses != nil {
+			return errUses
+		}
+		if !animates {
+			return fmt.Errorf("%s has no animation", s)
+		}
+	}
+
+	tmpDir, errTmp := os.MkdirTemp("", "aoc-")
+	if errTmp != nil {
+		return errTmp
+	}
+	defer os.RemoveAll(tmpDir)
+
+	bin, errBuild := s.build(tmpDir)
+	if errBuild != nil {
+		return errBuild
+	}
+
+	tracePath := *traceFile
+	if *explain && (tracePath == "" || tracePath == "-") {
+		tracePath = filepath.Join(tmpDir, "trace.jsonl")
+	}
+	if tracePath != "" && tracePath != "-" {
+		absPath, errAbs := filepath.Abs(tracePath) // The solver runs from its own directory.
+		if errAbs != nil {
+			return errAbs
+		}
+		tracePath = absPath
+	}
+
+	env := []string{}
+	if tracePath != "" {
+		env = append(env, trace.EnvVar+"="+tracePath)
+	}
+	if *animate {
+		env = append(env, fmt.Sprintf("%s=%d", term.EnvVar, *fps))
+	}
+
+	if errRun := s.runAttached(bin, solverArgs, env...); errRun != nil {
+		return fmt.Errorf("%s failed: %w", s, errRun)
+	}
+
+	if !*explain {
+		return nil
+	}
+
+	return explainTrace(os.Stdout, tracePath)
+}
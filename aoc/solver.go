@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// findRoot walks up from the working directory until it finds the repository root,
+// which is recognised by the aoc module it contains.
+func findRoot() (string, error) {
+	dir, errWd := os.Getwd()
+	if errWd != nil {
+		return "", errWd
+	}
+
+	for {
+		if _, errStat := os.Stat(filepath.Join(dir, "aoc", "go.mod")); errStat == nil {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("cannot find repository root: not inside the advent-of-code repository")
+		}
+		dir = parent
+	}
+}
+
+// parseDayPart parses the <day> <part> positional arguments shared by most commands.
+func parseDayPart(args []string) (int, int, error) {
+	if len(args) != 2 {
+		return 0, 0, fmt.Errorf("expected <day> <part>, got %d arguments", len(args))
+	}
+
+	day, errDay := strconv.Atoi(args[0])
+	if errDay != nil || day < 1 || day > 25 {
+		return 0, 0, fmt.Errorf("invalid day: %s", args[0])
+	}
+
+	part, errPart := strconv.Atoi(args[1])
+	if errPart != nil || part < 1 || part > 2 {
+		return 0, 0, fmt.Errorf("invalid part: %s", args[1])
+	}
+
+	return day, part, nil
+}
+
+// solver is the solution program of one part of a puzzle.
+type solver struct {
+	key puzzleKey
+	dir string
+}
+
+// String returns a short human readable name of the solver.
+func (s solver) String() string {
+	return s.key.String()
+}
+
+// inputPath returns the path of the puzzle input read by the solver.
+func (s solver) inputPath() string {
+	return filepath.Join(s.dir, inputFileName)
+}
+
+// build compiles the solver into outDir and returns the path of the produced binary.
+func (s solver) build(outDir string) (string, error) {
+	bin := filepath.Join(outDir, fmt.Sprintf("%d-puzzle-%d-part-%d", s.key.year, s.key.day, s.key.part))
+
+	cmd := exec.Command("go", "build", "-o", bin, ".")
+	cmd.Dir = s.dir
+	output, errBuild := cmd.CombinedOutput()
+	if errBuild != nil {
+		return "", fmt.Errorf("cannot build %s: %w\n%s", s, errBuild, output)
+	}
+
+	return bin, nil
+}
+
+// run executes a binary built from the solver, from within the solver directory so it finds its input.
+// The given environment variables are added to the environment of the solver, which is killed if ctx is done.
+// It returns the answer, which is the last line printed by the solver, and the time the run took.
+func (s solver) run(ctx context.Context, bin string, env ...string) (string, time.Duration, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, bin)
+	cmd.Dir = s.dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	errRun := cmd.Run()
+	elapsed := time.Since(start)
+	if errCtx := ctx.Err(); errCtx != nil {
+		return "", elapsed, fmt.Errorf("%s did not finish: %w", s, errCtx)
+	}
+	if errRun != nil {
+		return "", elapsed, fmt.Errorf("%s failed: %w\n%s", s, errRun, stderr.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	answer := strings.TrimSpace(lines[len(lines)-1])
+	if answer == "" {
+		return "", elapsed, fmt.Errorf("%s printed no answer", s)
+	}
+
+	return answer, elapsed, nil
+}
+
+// solve builds the solver in a temporary directory, runs it and returns its answer and run time.
+func (s solver) solve() (string, time.Duration, error) {
+	tmpDir, errTmp := os.MkdirTemp("", "aoc-")
+	if errTmp != nil {
+		return "", 0, errTmp
+	}
+	defer os.RemoveAll(tmpDir)
+
+	bin, errBuild := s.build(tmpDir)
+	if errBuild != nil {
+		return "", 0, errBuild
+	}
+
+	return s.run(context.Background(), bin)
+}
+
+// splitSolverArgs splits positional arguments at the first "--", separating those of the command
+// from those forwarded to the solver.
+func splitSolverArgs(args []string) ([]string, []string) {
+	for i, arg := range args {
+		if arg == "--" {
+			return args[:i], args[i+1:]
+		}
+	}
+
+	return args, nil
+}
+
+// runAttached executes a binary built from the solver with the terminal attached to it, for interactive use.
+// The solver is given the arguments, and the environment variables are added to its environment.
+func (s solver) runAttached(bin string, args []string, env ...string) error {
+	cmd := exec.Command(bin, args...)
+	cmd.Dir = s.dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+// acceptsFlag returns true if a binary built from the solver defines the given flag, as listed by its -h output.
+func (s solver) acceptsFlag(bin, name string) (bool, error) {
+	cmd := exec.Command(bin, "-h")
+	cmd.Dir = s.dir
+	output, errRun := cmd.CombinedOutput()
+
+	// The flag package exits with status 2 after printing the usage.
+	var errExit *exec.ExitError
+	if errRun != nil && !errors.As(errRun, &errExit) {
+		return false, errRun
+	}
+
+	return regexp.MustCompile(`(?m)^  -` + regexp.QuoteMeta(name) + `(\s|$)`).Match(output), nil
+}
+
+// uses returns true if the sources of the solver import the given package.
+func (s solver) uses(importPath string) (bool, error) {
+	sources, errGlob := filepath.Glob(filepath.Join(s.dir, "*.go"))
+	if errGlob != nil {
+		return false, errGlob
+	}
+
+	for _, source := range sources {
+		content, errRead := os.ReadFile(source)
+		if errRead != nil {
+			return false, errRead
+		}
+
+		if bytes.Contains(content, []byte(strconv.Quote(importPath))) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultBaseURL is the address of the Advent of Code server.
+const defaultBaseURL = "https://adventofcode.com"
+
+// userAgent identifies the tool to the Advent of Code server, as its maintainers ask automated tools to do.
+const userAgent = "github.com/maaxleq/advent-of-code-2023/aoc"
+
+// outcome is the verdict of the server on a submitted answer.
+type outcome int
+
+const (
+	outcomeUnknown outcome = iota
+	outcomeCorrect
+	outcomeTooHigh
+	outcomeTooLow
+	outcomeWrong // The answer is wrong, and the server did not tell whether it is too high or too low.
+	outcomeAlreadySolved
+	outcomeRateLimited
+)
+
+// outcomeNames maps each outcome to its name, as shown to the user and stored in the guess log.
+var outcomeNames = map[outcome]string{
+	outcomeUnknown:       "unknown",
+	outcomeCorrect:       "correct",
+	outcomeTooHigh:       "too high",
+	outcomeTooLow:        "too low",
+	outcomeWrong:         "wrong",
+	outcomeAlreadySolved: "already solved",
+	outcomeRateLimited:   "rate limited",
+}
+
+// String returns the name of the outcome.
+func (o outcome) String() string {
+	return outcomeNames[o]
+}
+
+// MarshalText encodes the outcome as its name.
+func (o outcome) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText decodes an outcome from its name.
+func (o *outcome) UnmarshalText(text []byte) error {
+	for candidate, name := range outcomeNames {
+		if name == string(text) {
+			*o = candidate
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid outcome: %s", text)
+}
+
+// isWrong returns true if the outcome proves the submitted answer is not the right one.
+func (o outcome) isWrong() bool {
+	return o == outcomeTooHigh || o == outcomeTooLow || o == outcomeWrong
+}
+
+// submitResult is the parsed response of the server to a submission.
+type submitResult struct {
+	outcome outcome
+	wait    time.Duration // How long to wait before submitting again, if the server said so.
+	message string        // The text of the response, stripped of its markup.
+}
+
+var (
+	articleRegexp  = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRegexp      = regexp.MustCompile(`<[^>]*>`)
+	spaceRegexp    = regexp.MustCompile(`\s+`)
+	leftWaitRegexp = regexp.MustCompile(`[Yy]ou have (?:(\d+)m )?(\d+)s left to wait`)
+	penaltyRegexp  = regexp.MustCompile(`[Pp]lease wait (one|\d+) minutes? before trying again`)
+)
+
+// parseSubmitResponse extracts the verdict from the HTML page returned by the server after a submission.
+func parseSubmitResponse(page string) submitResult {
+	text := page
+	if match := articleRegexp.FindStringSubmatch(page); match != nil {
+		text = match[1]
+	}
+	text = html.UnescapeString(tagRegexp.ReplaceAllString(text, " "))
+	text = strings.TrimSpace(spaceRegexp.ReplaceAllString(text, " "))
+
+	res := submitResult{message: text}
+
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		res.outcome = outcomeCorrect
+	case strings.Contains(text, "your answer is too high"):
+		res.outcome = outcomeTooHigh
+	case strings.Contains(text, "your answer is too low"):
+		res.outcome = outcomeTooLow
+	case strings.Contains(text, "That's not the right answer"):
+		res.outcome = outcomeWrong
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		res.outcome = outcomeAlreadySolved
+	case strings.Contains(text, "You gave an answer too recently"):
+		res.outcome = outcomeRateLimited
+	}
+
+	if match := leftWaitRegexp.FindStringSubmatch(text); match != nil {
+		minutes, _ := strconv.Atoi(match[1])
+		seconds, _ := strconv.Atoi(match[2])
+		res.wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if match := penaltyRegexp.FindStringSubmatch(text); match != nil {
+		minutes, errConv := strconv.Atoi(match[1])
+		if errConv != nil { // "one minute"
+			minutes = 1
+		}
+		res.wait = time.Duration(minutes) * time.Minute
+	}
+
+	return res
+}
+
+// client talks to an Advent of Code server on behalf of a logged in user.
+type client struct {
+	baseURL string
+	session string
+	http    *http.Client
+}
+
+// submit posts an answer for a puzzle part and returns the parsed verdict of the server.
+func (c *client) submit(key puzzleKey, answer string) (submitResult, error) {
+	endpoint := fmt.Sprintf("%s/%d/day/%d/answer", strings.TrimSuffix(c.baseURL, "/"), key.year, key.day)
+	form := url.Values{
+		"level":  {strconv.Itoa(key.part)},
+		"answer": {answer},
+	}
+
+	req, errReq := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if errReq != nil {
+		return submitResult{}, errReq
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", userAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.session})
+
+	resp, errDo := c.http.Do(req)
+	if errDo != nil {
+		return submitResult{}, fmt.Errorf("cannot submit answer: %w", errDo)
+	}
+	defer resp.Body.Close()
+
+	body, errBody := io.ReadAll(resp.Body)
+	if errBody != nil {
+		return submitResult{}, fmt.Errorf("cannot read response: %w", errBody)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return submitResult{}, fmt.Errorf("cannot submit answer: server responded %s", resp.Status)
+	}
+
+	return parseSubmitResponse(string(body)), nil
+}
+
+// runSubmit implements the submit command, which solves a puzzle part and submits the answer.
+func runSubmit(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ExitOnError)
+	baseURL := fs.String("base-url", envOr("AOC_BASE_URL", defaultBaseURL), "address of the Advent of Code server")
+	session := fs.String("session", os.Getenv("AOC_SESSION"), "session cookie of the logged in user")
+	answer := fs.String("answer", "", "answer to submit instead of running the solver")
+	year := addYearFlag(fs)
+	fs.Parse(args)
+
+	if *session == "" {
+		return fmt.Errorf("no session cookie: set AOC_SESSION or pass -session")
+	}
+
+	root, s, errSolver := findSolver(*year, fs.Args())
+	if errSolver != nil {
+		return errSolver
+	}
+	key := s.key
+
+	if *answer == "" {
+		solved, elapsed, errSolve := s.solve()
+		if errSolve != nil {
+			return errSolve
+		}
+		fmt.Printf("%s: %s (%s)\n", s, solved, elapsed.Round(time.Millisecond))
+		*answer = solved
+	}
+
+	gl, errLog := loadGuessLog(filepath.Join(root, guessLogFile))
+	if errLog != nil {
+		return errLog
+	}
+
+	if errCheck := gl.check(key, *answer); errCheck != nil {
+		return fmt.Errorf("not submitting: %w", errCheck)
+	}
+
+	c := &client{
+		baseURL: *baseURL,
+		session: *session,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+
+	res, errSubmit := c.submit(key, *answer)
+	if errSubmit != nil {
+		return errSubmit
+	}
+
+	switch res.outcome {
+	case outcomeRateLimited:
+		fmt.Printf("%s: wait %s before submitting again\n", res.outcome, res.wait)
+	case outcomeUnknown:
+		fmt.Printf("%s: %s\n", res.outcome, res.message)
+	default:
+		fmt.Println(res.outcome)
+		if res.wait > 0 {
+			fmt.Printf("wait %s before submitting again\n", res.wait)
+		}
+	}
+
+	// Only verdicts on the answer itself are worth remembering.
+	if res.outcome == outcomeCorrect || res.outcome.isWrong() {
+		return gl.add(guess{
+			Year:    key.year,
+			Day:     key.day,
+			Part:    key.part,
+			Answer:  *answer,
+			Outcome: res.outcome,
+			Time:    time.Now(),
+		})
+	}
+
+	return nil
+}
+
+// envOr returns the value of the environment variable key, or fallback if it is not set.
+func envOr(key, fallback string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+
+	return fallback
+}
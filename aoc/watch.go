@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// fileStamp identifies a version of a file by its modification time and size.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// snapshot maps watched file paths to their current stamp.
+type snapshot map[string]fileStamp
+
+// changedFiles returns the files which were added, modified or removed between two snapshots.
+func (s snapshot) changedFiles(previous snapshot) []string {
+	changed := []string{}
+
+	for path, stamp := range s {
+		if prevStamp, exists := previous[path]; !exists || prevStamp != stamp {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range previous {
+		if _, exists := s[path]; !exists {
+			changed = append(changed, path)
+		}
+	}
+
+	sort.Strings(changed)
+	return changed
+}
+
+// watchedFiles returns the files whose change triggers a new run of the solver:
+// its input and the Go sources of every part of its day.
+func (s solver) watchedFiles() ([]string, error) {
+	sources, errGlob := filepath.Glob(filepath.Join(filepath.Dir(s.dir), "*", "*.go"))
+	if errGlob != nil {
+		return nil, errGlob
+	}
+
+	return append(sources, s.inputPath()), nil
+}
+
+// takeSnapshot stamps every watched file of the solver. Missing files are left out of the snapshot.
+func (s solver) takeSnapshot() (snapshot, error) {
+	files, errFiles := s.watchedFiles()
+	if errFiles != nil {
+		return nil, errFiles
+	}
+
+	snap := make(snapshot)
+	for _, file := range files {
+		info, errStat := os.Stat(file)
+		if errors.Is(errStat, os.ErrNotExist) {
+			continue
+		}
+		if errStat != nil {
+			return nil, errStat
+		}
+
+		snap[file] = fileStamp{
+			modTime: info.ModTime(),
+			size:    info.Size(),
+		}
+	}
+
+	return snap, nil
+}
+
+// describeAnswerChange compares an answer to the one of the previous run.
+// Numeric answers also show by how much they changed.
+func describeAnswerChange(previous, current string, first bool) string {
+	if first {
+		return "first run"
+	}
+
+	if previous == current {
+		return "unchanged"
+	}
+
+	if previous == "" {
+		return "previous run failed"
+	}
+
+	prevNum, errPrev := strconv.ParseInt(previous, 10, 64)
+	curNum, errCur := strconv.ParseInt(current, 10, 64)
+	if errPrev == nil && errCur == nil {
+		return fmt.Sprintf("was %s (%+d)", previous, curNum-prevNum)
+	}
+
+	return fmt.Sprintf("was %s", previous)
+}
+
+// runWatch implements the watch command, which rebuilds and reruns a solver every time its input or sources change.
+func runWatch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	interval := fs.Duration("interval", 500*time.Millisecond, "how often to look for changes")
+	year := addYearFlag(fs)
+	fs.Parse(args)
+
+	root, s, errSolver := findSolver(*year, fs.Args())
+	if errSolver != nil {
+		return errSolver
+	}
+
+	tmpDir, errTmp := os.MkdirTemp("", "aoc-watch-")
+	if errTmp != nil {
+		return errTmp
+	}
+	defer os.RemoveAll(tmpDir)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("watching %s, press Ctrl+C to stop\n", s)
+
+	var previous snapshot
+	var previousAnswer string
+	first := true
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	for {
+		current, errSnap := s.takeSnapshot()
+		if errSnap != nil {
+			return errSnap
+		}
+
+		if changed := current.changedFiles(previous); first || len(changed) > 0 {
+			if !first {
+				for _, file := range changed {
+					rel, _ := filepath.Rel(root, file)
+					fmt.Printf("changed: %s\n", rel)
+				}
+			}
+
+			answer := ""
+			bin, errBuild := s.build(tmpDir)
+			if errBuild == nil {
+				var elapsed time.Duration
+				var errRun error
+				answer, elapsed, errRun = s.run(ctx, bin)
+				if errRun != nil {
+					fmt.Fprintf(os.Stderr, "[%s] %v\n", time.Now().Format(time.TimeOnly), errRun)
+				} else {
+					fmt.Printf("[%s] %s: %s in %s, %s\n", time.Now().Format(time.TimeOnly), s, answer,
+						elapsed.Round(time.Microsecond), describeAnswerChange(previousAnswer, answer, first))
+				}
+			} else {
+				fmt.Fprintf(os.Stderr, "[%s] %v\n", time.Now().Format(time.TimeOnly), errBuild)
+			}
+
+			previousAnswer = answer
+			first = false
+		}
+		previous = current
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Package cycle finds where a sequence of states x0, f(x0), f(f(x0)), ... starts repeating,
+// so that the state after a huge number of iterations can be known without computing every one of them.
+//
+// The sequence is defined by an initial state and a step function, which must return a new state
+// and leave its argument untouched: the finders keep some of the states they are given.
+// States are compared through a key function, which maps equal states, and only equal states, to the same key.
+package cycle
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNoCycle is returned when no state repeats within the iteration limit.
+// All the finders agree on it: given a limit, they find a cycle if and only if the state at some index
+// up to limit repeats an earlier state, that is if Start+Period <= limit.
+var ErrNoCycle = errors.New("no cycle found")
+
+// Result describes a sequence of states which eventually repeats: the state at index Start+Period
+// is the state at index Start, and every following state repeats with the same period.
+type Result[S any] struct {
+	Start  int // Index of the first state of the cycle.
+	Period int // Number of states in the cycle.
+
+	history []S // States at indices 0 to Start+Period-1.
+}
+
+// StateAt returns the state at index n of the sequence, the initial state being at index 0,
+// using the states recorded while finding the cycle.
+func (r Result[S]) StateAt(n int) S {
+	if n >= r.Start {
+		n = r.Start + (n-r.Start)%r.Period
+	}
+
+	return r.history[n]
+}
+
+// Hashed finds the cycle by remembering the key of every state in a map, stopping at the first repeated key.
+// It computes each state once, at the cost of keeping every key until the cycle closes.
+// It returns ErrNoCycle if no state repeats within the first limit+1 states.
+func Hashed[S any, K comparable](initial S, step func(S) S, key func(S) K, limit int) (Result[S], error) {
+	seen := make(map[K]int)
+	history := []S{}
+
+	state := initial
+	for i := 0; i <= limit; i++ {
+		k := key(state)
+		if start, exists := seen[k]; exists {
+			return Result[S]{
+				Start:   start,
+				Period:  i - start,
+				history: history,
+			}, nil
+		}
+
+		seen[k] = i
+		history = append(history, state)
+		state = step(state)
+	}
+
+	return Result[S]{}, fmt.Errorf("%w within %d iterations", ErrNoCycle, limit)
+}
+
+// Floyd finds the cycle with Floyd's tortoise and hare algorithm, which keeps only two states while
+// looking for the cycle, then records the states before it closes.
+// It returns ErrNoCycle if no state repeats within the first limit+1 states. Looking for the cycle may
+// take up to 3*limit steps, as the hare runs ahead of the tortoise.
+func Floyd[S any, K comparable](initial S, step func(S) S, key func(S) K, limit int) (Result[S], error) {
+	// Move the hare twice as fast as the tortoise until they meet inside the cycle.
+	// They meet at the first index i of the tortoise which is in the cycle and a multiple of the period,
+	// so at the latest at index Start+Period.
+	tortoise, hare := step(initial), step(step(initial))
+	for i := 1; key(tortoise) != key(hare); i++ {
+		if i >= limit {
+			return Result[S]{}, fmt.Errorf("%w within %d iterations", ErrNoCycle, limit)
+		}
+
+		tortoise = step(tortoise)
+		hare = step(step(hare))
+	}
+
+	// The distance between them is now a multiple of the period: restarting the tortoise from the
+	// initial state and moving both at the same speed, they meet at the start of the cycle.
+	start := 0
+	tortoise = initial
+	for key(tortoise) != key(hare) {
+		tortoise = step(tortoise)
+		hare = step(hare)
+		start++
+	}
+
+	period := 1
+	startKey := key(tortoise)
+	for hare = step(tortoise); key(hare) != startKey; hare = step(hare) {
+		period++
+	}
+
+	if start+period > limit {
+		return Result[S]{}, fmt.Errorf("%w within %d iterations", ErrNoCycle, limit)
+	}
+
+	return record(initial, step, start, period), nil
+}
+
+// Brent finds the cycle with Brent's algorithm, which keeps only two states while looking for the cycle
+// and usually needs fewer steps than Floyd, then records the states before it closes.
+// It returns ErrNoCycle if no state repeats within the first limit+1 states. Looking for the cycle may
+// take up to 3*limit steps, as the hare only meets the tortoise once the power of two exceeds the period.
+func Brent[S any, K comparable](initial S, step func(S) S, key func(S) K, limit int) (Result[S], error) {
+	// Find the period by moving the tortoise to the hare at every power of two, until the hare meets it.
+	// Only the key of the tortoise is needed to compare it with the hare.
+	// The hare meets the tortoise once the power is at least Start and Period, so at the latest at
+	// index 3*(Start+Period): past maxHareIndex, the cycle cannot close within the limit.
+	maxHareIndex := math.MaxInt
+	if limit < math.MaxInt/3-1 {
+		maxHareIndex = 3 * (limit + 1)
+	}
+
+	power, period := 1, 1
+	tortoiseKey, hare := key(initial), step(initial)
+	for i := 1; tortoiseKey != key(hare); i++ {
+		if i >= maxHareIndex {
+			return Result[S]{}, fmt.Errorf("%w within %d iterations", ErrNoCycle, limit)
+		}
+
+		if power == period {
+			tortoiseKey = key(hare)
+			power *= 2
+			period = 0
+		}
+
+		hare = step(hare)
+		period++
+	}
+
+	// Start the hare one period ahead of the tortoise: they meet at the start of the cycle.
+	tortoise := initial
+	hare = initial
+	for i := 0; i < period; i++ {
+		hare = step(hare)
+	}
+
+	start := 0
+	for key(tortoise) != key(hare) {
+		tortoise = step(tortoise)
+		hare = step(hare)
+		start++
+	}
+
+	if start+period > limit {
+		return Result[S]{}, fmt.Errorf("%w within %d iterations", ErrNoCycle, limit)
+	}
+
+	return record(initial, step, start, period), nil
+}
+
+// record computes the states before the cycle closes, from which the result answers StateAt.
+func record[S any](initial S, step func(S) S, start, period int) Result[S] {
+	history := make([]S, start+period)
+
+	state := initial
+	for i := range history {
+		history[i] = state
+		if i != len(history)-1 {
+			state = step(state)
+		}
+	}
+
+	return Result[S]{
+		Start:   start,
+		Period:  period,
+		history: history,
+	}
+}
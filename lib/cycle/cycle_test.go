@@ -0,0 +1,100 @@
+package cycle
+
+import (
+	"errors"
+	"testing"
+)
+
+// finder is the signature shared by the cycle finders, on int states keyed by themselves.
+type finder func(initial int, step func(int) int, key func(int) int, limit int) (Result[int], error)
+
+var finders = map[string]finder{
+	"Hashed": Hashed[int, int],
+	"Floyd":  Floyd[int, int],
+	"Brent":  Brent[int, int],
+}
+
+// rho returns the step function of a sequence 0, 1, 2, ... which loops back to start after start+period-1.
+func rho(start, period int) func(int) int {
+	return func(x int) int {
+		if x+1 == start+period {
+			return start
+		}
+		return x + 1
+	}
+}
+
+func identity(x int) int {
+	return x
+}
+
+func TestFindersAgree(t *testing.T) {
+	for start := 0; start < 20; start++ {
+		for period := 1; period < 20; period++ {
+			step := rho(start, period)
+
+			for name, find := range finders {
+				res, err := find(0, step, identity, start+period)
+				if err != nil {
+					t.Fatalf("%s(start %d, period %d): %v", name, start, period, err)
+				}
+				if res.Start != start || res.Period != period {
+					t.Fatalf("%s: got start %d, period %d, want %d, %d", name, res.Start, res.Period, start, period)
+				}
+
+				// Walk the sequence well past the cycle, checking every state.
+				state := 0
+				for n := 0; n < 3*(start+period); n++ {
+					if got := res.StateAt(n); got != state {
+						t.Fatalf("%s(start %d, period %d): StateAt(%d) = %d, want %d", name, start, period, n, got, state)
+					}
+					state = step(state)
+				}
+			}
+		}
+	}
+}
+
+func TestLimitBoundary(t *testing.T) {
+	tests := []struct {
+		name          string
+		start, period int
+	}{
+		{"pure cycle", 0, 10},
+		{"fixed point", 0, 1},
+		{"tail and cycle", 7, 3},
+		{"long tail", 30, 1},
+		{"power of two", 8, 8},
+	}
+
+	for _, tt := range tests {
+		step := rho(tt.start, tt.period)
+		closing := tt.start + tt.period // Index of the first repeated state.
+
+		for name, find := range finders {
+			if _, err := find(0, step, identity, closing); err != nil {
+				t.Errorf("%s, %s: limit %d: %v", tt.name, name, closing, err)
+			}
+			if _, err := find(0, step, identity, closing-1); !errors.Is(err, ErrNoCycle) {
+				t.Errorf("%s, %s: limit %d: got %v, want ErrNoCycle", tt.name, name, closing-1, err)
+			}
+		}
+	}
+}
+
+func TestNoCycle(t *testing.T) {
+	for name, find := range finders {
+		calls := 0
+		step := func(x int) int {
+			calls++
+			return x + 1
+		}
+
+		if _, err := find(0, step, identity, 100); !errors.Is(err, ErrNoCycle) {
+			t.Errorf("%s: got %v, want ErrNoCycle", name, err)
+		}
+		if calls > 3*(100+1)+2 {
+			t.Errorf("%s: %d steps for a limit of 100", name, calls)
+		}
+	}
+}
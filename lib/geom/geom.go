@@ -0,0 +1,164 @@
+// Package geom provides integer plane geometry: points, directions, distances and polygon areas.
+// Coordinates follow the grid convention of puzzle inputs, with x growing to the right and y growing downwards.
+package geom
+
+import "fmt"
+
+// Point is a position on the integer plane.
+type Point struct {
+	X, Y int
+}
+
+// String returns the point as (x, y).
+func (p Point) String() string {
+	return fmt.Sprintf("(%d, %d)", p.X, p.Y)
+}
+
+// Add returns the sum of two points, seen as vectors.
+func (p Point) Add(q Point) Point {
+	return Point{X: p.X + q.X, Y: p.Y + q.Y}
+}
+
+// Sub returns the difference of two points, seen as vectors.
+func (p Point) Sub(q Point) Point {
+	return Point{X: p.X - q.X, Y: p.Y - q.Y}
+}
+
+// Scale returns the point multiplied by k, seen as a vector.
+func (p Point) Scale(k int) Point {
+	return Point{X: p.X * k, Y: p.Y * k}
+}
+
+// Move returns the point one step away from p in direction d.
+func (p Point) Move(d Direction) Point {
+	return p.Add(d.Delta())
+}
+
+// Abs returns the absolute value of n.
+func Abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// Manhattan returns the taxicab distance between two points, the number of orthogonal steps between them.
+func Manhattan(p, q Point) int {
+	return Abs(p.X-q.X) + Abs(p.Y-q.Y)
+}
+
+// Chebyshev returns the chessboard distance between two points, the number of king moves between them.
+// Two distinct points are adjacent, diagonally or not, if their Chebyshev distance is 1.
+func Chebyshev(p, q Point) int {
+	return max(Abs(p.X-q.X), Abs(p.Y-q.Y))
+}
+
+// DoubleArea returns twice the area of the polygon with the given vertices, listed in order around it,
+// using the shoelace formula. Twice the area of a polygon with integer vertices is always an integer.
+func DoubleArea(vertices []Point) int {
+	sum := 0
+	for i, p := range vertices {
+		q := vertices[(i+1)%len(vertices)]
+		sum += p.X*q.Y - q.X*p.Y
+	}
+
+	return Abs(sum)
+}
+
+// Area returns the area of the polygon with the given vertices, listed in order around it, rounded down.
+func Area(vertices []Point) int {
+	return DoubleArea(vertices) / 2
+}
+
+// Direction is one of the four orthogonal directions.
+type Direction int
+
+// The directions are listed clockwise, so that turning is adding or removing one.
+const (
+	North Direction = iota
+	East
+	South
+	West
+)
+
+// Directions lists the four directions, clockwise from North.
+var Directions = []Direction{North, East, South, West}
+
+// directionNames maps each direction to its name.
+var directionNames = map[Direction]string{
+	North: "north",
+	East:  "east",
+	South: "south",
+	West:  "west",
+}
+
+// String returns the name of the direction.
+func (d Direction) String() string {
+	if name, exists := directionNames[d]; exists {
+		return name
+	}
+
+	return fmt.Sprintf("Direction(%d)", int(d))
+}
+
+// Delta returns the move of one step in the direction.
+func (d Direction) Delta() Point {
+	switch d {
+	case North:
+		return Point{X: 0, Y: -1}
+	case East:
+		return Point{X: 1, Y: 0}
+	case South:
+		return Point{X: 0, Y: 1}
+	case West:
+		return Point{X: -1, Y: 0}
+	default:
+		panic(fmt.Sprintf("geom: invalid direction %d", int(d)))
+	}
+}
+
+// TurnRight returns the direction a quarter turn clockwise from d.
+func (d Direction) TurnRight() Direction {
+	return (d + 1) % 4
+}
+
+// TurnLeft returns the direction a quarter turn counterclockwise from d.
+func (d Direction) TurnLeft() Direction {
+	return (d + 3) % 4
+}
+
+// Reverse returns the opposite direction.
+func (d Direction) Reverse() Direction {
+	return (d + 2) % 4
+}
+
+// Horizontal returns true for East and West.
+func (d Direction) Horizontal() bool {
+	return d == East || d == West
+}
+
+// Vertical returns true for North and South.
+func (d Direction) Vertical() bool {
+	return d == North || d == South
+}
+
+// ReflectSlash returns the direction of a ray going in direction d after bouncing on a '/' mirror:
+// east becomes north, north becomes east, west becomes south and south becomes west.
+func (d Direction) ReflectSlash() Direction {
+	if d.Horizontal() {
+		return d.TurnLeft()
+	}
+
+	return d.TurnRight()
+}
+
+// ReflectBackslash returns the direction of a ray going in direction d after bouncing on a '\' mirror:
+// east becomes south, south becomes east, west becomes north and north becomes west.
+func (d Direction) ReflectBackslash() Direction {
+	if d.Horizontal() {
+		return d.TurnRight()
+	}
+
+	return d.TurnLeft()
+}
@@ -0,0 +1,65 @@
+package geom
+
+import "testing"
+
+func TestDistances(t *testing.T) {
+	tests := []struct {
+		p, q      Point
+		manhattan int
+		chebyshev int
+	}{
+		{Point{0, 0}, Point{0, 0}, 0, 0},
+		{Point{0, 0}, Point{1, 1}, 2, 1},
+		{Point{2, -3}, Point{-1, 1}, 7, 4},
+		{Point{5, 5}, Point{5, -5}, 10, 10},
+	}
+
+	for _, tt := range tests {
+		if got := Manhattan(tt.p, tt.q); got != tt.manhattan {
+			t.Errorf("Manhattan(%v, %v) = %d, want %d", tt.p, tt.q, got, tt.manhattan)
+		}
+		if got := Manhattan(tt.q, tt.p); got != tt.manhattan {
+			t.Errorf("Manhattan(%v, %v) = %d, want %d", tt.q, tt.p, got, tt.manhattan)
+		}
+		if got := Chebyshev(tt.p, tt.q); got != tt.chebyshev {
+			t.Errorf("Chebyshev(%v, %v) = %d, want %d", tt.p, tt.q, got, tt.chebyshev)
+		}
+	}
+}
+
+func TestArea(t *testing.T) {
+	tests := []struct {
+		name     string
+		vertices []Point
+		double   int
+	}{
+		{"unit square", []Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}}, 2},
+		{"clockwise square", []Point{{0, 0}, {0, 1}, {1, 1}, {1, 0}}, 2},
+		{"odd triangle", []Point{{0, 0}, {3, 0}, {0, 3}}, 9},
+		{"L shape", []Point{{0, 0}, {4, 0}, {4, 1}, {1, 1}, {1, 3}, {0, 3}}, 12},
+		{"negative coordinates", []Point{{-2, -2}, {2, -2}, {2, 2}, {-2, 2}}, 32},
+	}
+
+	for _, tt := range tests {
+		if got := DoubleArea(tt.vertices); got != tt.double {
+			t.Errorf("%s: DoubleArea = %d, want %d", tt.name, got, tt.double)
+		}
+		if got := Area(tt.vertices); got != tt.double/2 {
+			t.Errorf("%s: Area = %d, want %d", tt.name, got, tt.double/2)
+		}
+	}
+}
+
+func TestDirections(t *testing.T) {
+	for _, d := range []Direction{North, East, South, West} {
+		if got := d.TurnRight().TurnLeft(); got != d {
+			t.Errorf("%v turned right then left is %v", d, got)
+		}
+		if got := d.Reverse().Reverse(); got != d {
+			t.Errorf("%v reversed twice is %v", d, got)
+		}
+		if got := (Point{}).Move(d).Add((Point{}).Move(d.Reverse())); got != (Point{}) {
+			t.Errorf("moving %v then back ends at %v", d, got)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/maaxleq/advent-of-code-2023/lib
+
+go 1.21.1
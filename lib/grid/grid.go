@@ -0,0 +1,243 @@
+// Package grid provides a generic rectangular 2D grid, the shape most puzzle inputs come in.
+package grid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Grid is a rectangular grid of cells of type T, addressed by their column x and row y,
+// with (0, 0) being the top left cell. Cells are stored row after row in a single slice.
+//
+// A Grid is a small value sharing its cells when copied, like a slice: use Clone to get an independent copy.
+type Grid[T any] struct {
+	width, height int
+	cells         []T
+}
+
+// New returns a grid of the given size with every cell set to the zero value of T.
+func New[T any](width, height int) Grid[T] {
+	return Grid[T]{
+		width:  width,
+		height: height,
+		cells:  make([]T, width*height),
+	}
+}
+
+// Parse builds a grid from the lines of an input, one row per line, converting every rune with parseCell.
+// It returns an error if a rune cannot be converted or if the lines are not all the same length.
+func Parse[T any](lines []string, parseCell func(r rune) (T, error)) (Grid[T], error) {
+	if len(lines) == 0 {
+		return Grid[T]{}, nil
+	}
+
+	width := len([]rune(lines[0]))
+	g := New[T](width, len(lines))
+
+	for y, line := range lines {
+		runes := []rune(line)
+		if len(runes) != width {
+			return Grid[T]{}, fmt.Errorf("cannot parse grid: line %d has %d cells, expected %d", y+1, len(runes), width)
+		}
+
+		for x, r := range runes {
+			cell, errCell := parseCell(r)
+			if errCell != nil {
+				return Grid[T]{}, fmt.Errorf("cannot parse grid at line %d, column %d: %w", y+1, x+1, errCell)
+			}
+
+			g.Set(x, y, cell)
+		}
+	}
+
+	return g, nil
+}
+
+// Runes builds a grid holding the runes of the lines of an input as they are.
+// It returns an error if the lines are not all the same length.
+func Runes(lines []string) (Grid[rune], error) {
+	return Parse(lines, func(r rune) (rune, error) {
+		return r, nil
+	})
+}
+
+// Width returns the number of columns of the grid.
+func (g Grid[T]) Width() int {
+	return g.width
+}
+
+// Height returns the number of rows of the grid.
+func (g Grid[T]) Height() int {
+	return g.height
+}
+
+// InBounds returns true if (x, y) is a cell of the grid.
+func (g Grid[T]) InBounds(x, y int) bool {
+	return x >= 0 && x < g.width && y >= 0 && y < g.height
+}
+
+// At returns the cell at (x, y). It panics if (x, y) is out of bounds.
+func (g Grid[T]) At(x, y int) T {
+	return g.cells[g.index(x, y)]
+}
+
+// Set changes the cell at (x, y). It panics if (x, y) is out of bounds.
+func (g Grid[T]) Set(x, y int, cell T) {
+	g.cells[g.index(x, y)] = cell
+}
+
+// index returns the position of the cell at (x, y) in the cells slice.
+func (g Grid[T]) index(x, y int) int {
+	if !g.InBounds(x, y) {
+		panic(fmt.Sprintf("grid: (%d, %d) out of bounds of %dx%d grid", x, y, g.width, g.height))
+	}
+
+	return y*g.width + x
+}
+
+// Find returns the coordinates of the first cell, in reading order, for which match returns true.
+// The last result is false if there is no such cell.
+func (g Grid[T]) Find(match func(cell T) bool) (int, int, bool) {
+	for i, cell := range g.cells {
+		if match(cell) {
+			return i % g.width, i / g.width, true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// Count returns the number of cells for which match returns true.
+func (g Grid[T]) Count(match func(cell T) bool) int {
+	count := 0
+	for _, cell := range g.cells {
+		if match(cell) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// offsets4 are the moves to the orthogonal neighbours of a cell: up, right, down and left.
+var offsets4 = [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+// offsets8 are the moves to the orthogonal and diagonal neighbours of a cell, clockwise from the top left one.
+var offsets8 = [][2]int{{-1, -1}, {0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}}
+
+// Neighbors4 calls fn with the coordinates of every orthogonal neighbour of (x, y) inside the grid.
+func (g Grid[T]) Neighbors4(x, y int, fn func(nx, ny int)) {
+	g.neighbors(x, y, offsets4, fn)
+}
+
+// Neighbors8 calls fn with the coordinates of every orthogonal or diagonal neighbour of (x, y) inside the grid.
+func (g Grid[T]) Neighbors8(x, y int, fn func(nx, ny int)) {
+	g.neighbors(x, y, offsets8, fn)
+}
+
+// neighbors calls fn with every cell reached from (x, y) by one of the offsets, skipping those out of the grid.
+func (g Grid[T]) neighbors(x, y int, offsets [][2]int, fn func(nx, ny int)) {
+	for _, offset := range offsets {
+		nx, ny := x+offset[0], y+offset[1]
+		if g.InBounds(nx, ny) {
+			fn(nx, ny)
+		}
+	}
+}
+
+// Row returns the cells of row y. The returned slice is a view: changing it changes the grid.
+func (g Grid[T]) Row(y int) []T {
+	if y < 0 || y >= g.height {
+		panic(fmt.Sprintf("grid: row %d out of bounds of %dx%d grid", y, g.width, g.height))
+	}
+
+	return g.cells[y*g.width : (y+1)*g.width : (y+1)*g.width]
+}
+
+// Col returns a copy of the cells of column x, from top to bottom.
+func (g Grid[T]) Col(x int) []T {
+	if x < 0 || x >= g.width {
+		panic(fmt.Sprintf("grid: column %d out of bounds of %dx%d grid", x, g.width, g.height))
+	}
+
+	col := make([]T, g.height)
+	for y := range col {
+		col[y] = g.cells[y*g.width+x]
+	}
+
+	return col
+}
+
+// Clone returns a copy of the grid which does not share its cells.
+func (g Grid[T]) Clone() Grid[T] {
+	clone := g
+	clone.cells = append([]T(nil), g.cells...)
+
+	return clone
+}
+
+// Transpose returns a new grid whose rows are the columns of g.
+func (g Grid[T]) Transpose() Grid[T] {
+	t := New[T](g.height, g.width)
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			t.Set(y, x, g.At(x, y))
+		}
+	}
+
+	return t
+}
+
+// RotateClockwise returns a new grid holding g turned a quarter turn clockwise.
+func (g Grid[T]) RotateClockwise() Grid[T] {
+	r := New[T](g.height, g.width)
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			r.Set(g.height-1-y, x, g.At(x, y))
+		}
+	}
+
+	return r
+}
+
+// RotateCounterClockwise returns a new grid holding g turned a quarter turn counterclockwise.
+func (g Grid[T]) RotateCounterClockwise() Grid[T] {
+	r := New[T](g.height, g.width)
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			r.Set(y, g.width-1-x, g.At(x, y))
+		}
+	}
+
+	return r
+}
+
+// Format draws the grid one row per line, writing each cell as returned by formatCell.
+func (g Grid[T]) Format(formatCell func(cell T) string) string {
+	var sb strings.Builder
+	for y := 0; y < g.height; y++ {
+		if y != 0 {
+			sb.WriteByte('\n')
+		}
+		for _, cell := range g.Row(y) {
+			sb.WriteString(formatCell(cell))
+		}
+	}
+
+	return sb.String()
+}
+
+// String draws the grid one row per line. Rune and byte cells are written as the character they hold,
+// so that a grid of runes is drawn the way it was written in the input, and other cells as formatted by fmt.
+func (g Grid[T]) String() string {
+	return g.Format(func(cell T) string {
+		switch c := any(cell).(type) {
+		case rune:
+			return string(c)
+		case byte:
+			return string(c)
+		default:
+			return fmt.Sprint(c)
+		}
+	})
+}
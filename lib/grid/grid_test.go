@@ -0,0 +1,112 @@
+package grid
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mustRunes(t *testing.T, rows ...string) Grid[rune] {
+	t.Helper()
+
+	g, err := Runes(rows)
+	if err != nil {
+		t.Fatalf("Runes(%q): %v", rows, err)
+	}
+
+	return g
+}
+
+func TestRunesRejectsRaggedRows(t *testing.T) {
+	if _, err := Runes([]string{"abc", "de"}); err == nil {
+		t.Fatal("Runes accepted rows of different lengths")
+	}
+}
+
+func TestTransformations(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform func(Grid[rune]) Grid[rune]
+		want      []string
+	}{
+		{"transpose", Grid[rune].Transpose, []string{"ad", "be", "cf"}},
+		{"clockwise", Grid[rune].RotateClockwise, []string{"da", "eb", "fc"}},
+		{"counterclockwise", Grid[rune].RotateCounterClockwise, []string{"cf", "be", "ad"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := mustRunes(t, "abc", "def")
+			got := tt.transform(g)
+
+			if want := strings.Join(tt.want, "\n"); got.String() != want {
+				t.Errorf("got\n%s\nwant\n%s", got, want)
+			}
+			if g.String() != "abc\ndef" {
+				t.Errorf("the original grid changed:\n%s", g)
+			}
+		})
+	}
+}
+
+func TestFourRotationsAreIdentity(t *testing.T) {
+	g := mustRunes(t, "ab", "cd", "ef")
+
+	clockwise, counterclockwise := g, g
+	for i := 0; i < 4; i++ {
+		clockwise = clockwise.RotateClockwise()
+		counterclockwise = counterclockwise.RotateCounterClockwise()
+	}
+
+	if clockwise.String() != g.String() || counterclockwise.String() != g.String() {
+		t.Errorf("four quarter turns changed the grid: %q and %q, want %q", clockwise, counterclockwise, g)
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := New[int](3, 3)
+
+	tests := []struct {
+		name  string
+		x, y  int
+		eight bool
+		want  [][2]int
+	}{
+		{"4 in a corner", 0, 0, false, [][2]int{{1, 0}, {0, 1}}},
+		{"4 on an edge", 1, 2, false, [][2]int{{1, 1}, {2, 2}, {0, 2}}},
+		{"4 in the middle", 1, 1, false, [][2]int{{1, 0}, {2, 1}, {1, 2}, {0, 1}}},
+		{"8 in a corner", 2, 2, true, [][2]int{{1, 1}, {2, 1}, {1, 2}}},
+		{"8 on an edge", 0, 1, true, [][2]int{{0, 0}, {1, 0}, {1, 1}, {1, 2}, {0, 2}}},
+		{"8 in the middle", 1, 1, true, [][2]int{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {2, 2}, {1, 2}, {0, 2}, {0, 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := [][2]int{}
+			collect := func(nx, ny int) {
+				got = append(got, [2]int{nx, ny})
+			}
+
+			if tt.eight {
+				g.Neighbors8(tt.x, tt.y, collect)
+			} else {
+				g.Neighbors4(tt.x, tt.y, collect)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("neighbours of (%d, %d): got %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRowIsAViewAndColACopy(t *testing.T) {
+	g := mustRunes(t, "ab", "cd")
+
+	g.Row(1)[0] = 'x'
+	g.Col(1)[0] = 'y'
+
+	if got := g.String(); got != "ab\nxd" {
+		t.Errorf("got\n%s\nwant\nab\nxd", got)
+	}
+}
@@ -0,0 +1,23 @@
+// Package input reads puzzle inputs.
+package input
+
+import (
+	"bufio"
+	"os"
+)
+
+// ReadLines reads a file and returns its contents as an array of strings.
+func ReadLines(filePath string) ([]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
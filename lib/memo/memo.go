@@ -0,0 +1,142 @@
+// Package memo provides typed memoization caches for dynamic programming solutions.
+//
+// Caches are keyed by any comparable type, typically a small struct holding the parameters of the
+// memoized function, so that looking up a value does not allocate.
+package memo
+
+import "fmt"
+
+// Stats counts the lookups and evictions of a cache.
+type Stats struct {
+	Hits      int // Lookups which found a value.
+	Misses    int // Lookups which did not find a value.
+	Evictions int // Values removed to make room for new ones in a bounded cache.
+}
+
+// HitRate returns the proportion of lookups which found a value, or 0 if there was no lookup.
+func (s Stats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// String summarizes the statistics.
+func (s Stats) String() string {
+	return fmt.Sprintf("%d hits, %d misses (%.1f%% hit rate), %d evictions", s.Hits, s.Misses, 100*s.HitRate(), s.Evictions)
+}
+
+// Cache maps keys to computed values. The zero value is not usable, create caches with New or NewBounded.
+type Cache[K comparable, V any] struct {
+	values map[K]V
+	stats  Stats
+
+	limit int // Maximum number of values, 0 for no limit.
+	order []K // Keys of a bounded cache, in a ring starting at next, from the oldest to the newest.
+	next  int
+}
+
+// New returns an empty cache which keeps every value it is given.
+func New[K comparable, V any]() *Cache[K, V] {
+	return &Cache[K, V]{
+		values: make(map[K]V),
+	}
+}
+
+// NewBounded returns an empty cache holding at most limit values. When full, it evicts the value added first
+// to make room for a new one. It panics if limit is not positive.
+func NewBounded[K comparable, V any](limit int) *Cache[K, V] {
+	if limit <= 0 {
+		panic(fmt.Sprintf("memo: invalid cache limit %d", limit))
+	}
+
+	return &Cache[K, V]{
+		values: make(map[K]V, limit),
+		limit:  limit,
+		order:  make([]K, 0, limit),
+	}
+}
+
+// Get returns the value stored for key, and whether there is one.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	value, exists := c.values[key]
+	if exists {
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+
+	return value, exists
+}
+
+// Put stores the value for key, evicting the oldest value if a bounded cache is full.
+func (c *Cache[K, V]) Put(key K, value V) {
+	if _, exists := c.values[key]; !exists && c.limit > 0 {
+		if len(c.order) < c.limit {
+			c.order = append(c.order, key)
+		} else {
+			delete(c.values, c.order[c.next])
+			c.stats.Evictions++
+			c.order[c.next] = key
+			c.next = (c.next + 1) % c.limit
+		}
+	}
+
+	c.values[key] = value
+}
+
+// Do returns the value stored for key, computing and storing it first if there is none.
+func (c *Cache[K, V]) Do(key K, compute func() V) V {
+	if value, exists := c.Get(key); exists {
+		return value
+	}
+
+	value := compute()
+	c.Put(key, value)
+
+	return value
+}
+
+// Len returns the number of values in the cache.
+func (c *Cache[K, V]) Len() int {
+	return len(c.values)
+}
+
+// Stats returns the statistics of the cache since it was created or reset.
+func (c *Cache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// Reset empties the cache and its statistics.
+func (c *Cache[K, V]) Reset() {
+	clear(c.values)
+	c.stats = Stats{}
+	c.order = c.order[:0]
+	c.next = 0
+}
+
+// Recursive memoizes a recursive function with the given cache. The function receives the memoized version
+// of itself, to use for its recursive calls, along with its argument:
+//
+//	fib := memo.Recursive(memo.New[int, int](), func(fib func(int) int, n int) int {
+//		if n < 2 {
+//			return n
+//		}
+//		return fib(n-1) + fib(n-2)
+//	})
+func Recursive[K comparable, V any](c *Cache[K, V], f func(recurse func(K) V, key K) V) func(K) V {
+	var memoized func(K) V
+	memoized = func(key K) V {
+		if value, exists := c.Get(key); exists {
+			return value
+		}
+
+		value := f(memoized, key)
+		c.Put(key, value)
+
+		return value
+	}
+
+	return memoized
+}
@@ -0,0 +1,114 @@
+package memo
+
+import "testing"
+
+func TestBoundedEviction(t *testing.T) {
+	c := NewBounded[int, string](3)
+	for _, k := range []int{1, 2, 3} {
+		c.Put(k, "v")
+	}
+
+	// Updating a key does not change the eviction order nor evict anything.
+	c.Put(1, "updated")
+	if c.Len() != 3 || c.Stats().Evictions != 0 {
+		t.Fatalf("after an update: %d values, %d evictions, want 3, 0", c.Len(), c.Stats().Evictions)
+	}
+
+	c.Put(4, "v") // Evicts 1, the oldest.
+	c.Put(5, "v") // Evicts 2.
+
+	tests := []struct {
+		key    int
+		exists bool
+	}{
+		{1, false},
+		{2, false},
+		{3, true},
+		{4, true},
+		{5, true},
+	}
+	for _, tt := range tests {
+		if _, exists := c.Get(tt.key); exists != tt.exists {
+			t.Errorf("Get(%d) found %v, want %v", tt.key, exists, tt.exists)
+		}
+	}
+
+	want := Stats{Hits: 3, Misses: 2, Evictions: 2}
+	if got := c.Stats(); got != want {
+		t.Errorf("stats: got %+v, want %+v", got, want)
+	}
+	if c.Len() != 3 {
+		t.Errorf("Len = %d, want 3", c.Len())
+	}
+}
+
+func TestDoCountsHitsAndMisses(t *testing.T) {
+	c := New[string, int]()
+	computed := 0
+	compute := func() int {
+		computed++
+		return 42
+	}
+
+	for i := 0; i < 3; i++ {
+		if got := c.Do("answer", compute); got != 42 {
+			t.Fatalf("Do = %d, want 42", got)
+		}
+	}
+
+	if computed != 1 {
+		t.Errorf("computed %d times, want 1", computed)
+	}
+	if got, want := c.Stats(), (Stats{Hits: 2, Misses: 1}); got != want {
+		t.Errorf("stats: got %+v, want %+v", got, want)
+	}
+	if got := c.Stats().HitRate(); got != 2.0/3 {
+		t.Errorf("HitRate = %v, want 2/3", got)
+	}
+}
+
+func TestReset(t *testing.T) {
+	c := NewBounded[int, int](2)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3)
+	c.Get(3)
+	c.Reset()
+
+	if c.Len() != 0 || c.Stats() != (Stats{}) {
+		t.Fatalf("after Reset: %d values, stats %+v", c.Len(), c.Stats())
+	}
+
+	// The ring starts over: the first key put is the first evicted.
+	c.Put(10, 10)
+	c.Put(11, 11)
+	c.Put(12, 12)
+	if _, exists := c.Get(10); exists {
+		t.Error("key 10 should have been evicted first")
+	}
+	if _, exists := c.Get(11); !exists {
+		t.Error("key 11 should still be cached")
+	}
+}
+
+func TestRecursive(t *testing.T) {
+	c := New[int, int]()
+	calls := 0
+	fib := Recursive(c, func(fib func(int) int, n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return fib(n-1) + fib(n-2)
+	})
+
+	if got := fib(50); got != 12586269025 {
+		t.Errorf("fib(50) = %d, want 12586269025", got)
+	}
+	if calls != 51 {
+		t.Errorf("computed %d values, want 51", calls)
+	}
+	if got := c.Stats(); got.Misses != 51 || got.Hits != 48 {
+		t.Errorf("stats: got %+v, want 51 misses and 48 hits", got)
+	}
+}
@@ -0,0 +1,86 @@
+package numtheory
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// GcdBig computes the Greatest Common Divisor of two big integers. The result is never negative.
+func GcdBig(a, b *big.Int) *big.Int {
+	return new(big.Int).GCD(nil, nil, a, b)
+}
+
+// LcmBig computes the Least Common Multiple of two big integers. The result is never negative, and is 0 if a or b is 0.
+func LcmBig(a, b *big.Int) *big.Int {
+	if a.Sign() == 0 || b.Sign() == 0 {
+		return new(big.Int)
+	}
+
+	l := new(big.Int).Quo(a, GcdBig(a, b))
+	l.Mul(l, b)
+	return l.Abs(l)
+}
+
+// LcmSliceBig computes the LCM of a slice of big integers.
+func LcmSliceBig(numbers []*big.Int) *big.Int {
+	if len(numbers) == 0 {
+		return new(big.Int) // No LCM for empty slice
+	}
+
+	result := new(big.Int).Set(numbers[0])
+	for _, number := range numbers[1:] {
+		result = LcmBig(result, number)
+	}
+	return result
+}
+
+// ExtendedGcdBig computes the Greatest Common Divisor g of a and b along with Bézout coefficients x and y,
+// such that a*x + b*y = g. The returned g is never negative.
+func ExtendedGcdBig(a, b *big.Int) (g, x, y *big.Int) {
+	x, y = new(big.Int), new(big.Int)
+	g = new(big.Int).GCD(x, y, a, b)
+	return g, x, y
+}
+
+// CrtBig solves the system of congruences x ≡ residues[i] (mod moduli[i]) like Crt, on big integers.
+// It returns ErrNoSolution if the congruences contradict each other.
+func CrtBig(residues, moduli []*big.Int) (*big.Int, *big.Int, error) {
+	if len(residues) != len(moduli) {
+		return nil, nil, fmt.Errorf("got %d residues for %d moduli", len(residues), len(moduli))
+	}
+
+	x, m := big.NewInt(0), big.NewInt(1)
+	for i, modulus := range moduli {
+		if modulus.Sign() <= 0 {
+			return nil, nil, fmt.Errorf("invalid modulus: %s", modulus)
+		}
+
+		// Find k such that x + m*k ≡ r (mod modulus), which requires gcd(m, modulus) to divide r - x.
+		r := new(big.Int).Mod(residues[i], modulus)
+		g, inv, _ := ExtendedGcdBig(m, modulus)
+		diff := new(big.Int).Sub(r, x)
+		quo, rem := new(big.Int).QuoRem(diff, g, new(big.Int))
+		if rem.Sign() != 0 {
+			return nil, nil, ErrNoSolution
+		}
+
+		step := new(big.Int).Quo(modulus, g)
+		k := new(big.Int).Mul(quo, inv)
+		k.Mod(k, step)
+
+		x.Add(x, k.Mul(k, m))
+		m.Mul(m, step)
+	}
+
+	return x, m, nil
+}
+
+// IsqrtBig returns the integer square root of n, the largest integer whose square is at most n.
+// It panics if n is negative.
+func IsqrtBig(n *big.Int) *big.Int {
+	if n.Sign() < 0 {
+		panic(fmt.Sprintf("numtheory: square root of negative number %s", n))
+	}
+
+	return new(big.Int).Sqrt(n)
+}
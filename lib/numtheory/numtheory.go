@@ -0,0 +1,212 @@
+// Package numtheory provides number theory functions shared by the puzzles.
+//
+// Functions work on machine integers, with generic or int64 signatures, and have a math/big
+// counterpart suffixed with Big for when the numbers involved do not fit. Multiplying big.Int
+// values cannot overflow, so MulChecked and the Checked variants of the LCM have no such counterpart.
+package numtheory
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// ErrNoSolution is returned when a system of congruences has no solution.
+var ErrNoSolution = errors.New("congruences have no common solution")
+
+// ErrOverflow is returned when a result does not fit in an int64.
+var ErrOverflow = errors.New("integer overflow")
+
+// Integer is satisfied by every integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Gcd computes the Greatest Common Divisor using the Euclidean algorithm.
+// The result is never negative, and Gcd(0, 0) is 0.
+func Gcd[T Integer](a, b T) T {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Lcm computes the Least Common Multiple of two numbers. The result is never negative, and is 0 if a or b is 0.
+// Like the arithmetic of T, it silently wraps around if the LCM does not fit in T: use LcmChecked to detect it,
+// or LcmBig for numbers of any size.
+func Lcm[T Integer](a, b T) T {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	l := a / Gcd(a, b) * b
+	if l < 0 {
+		return -l
+	}
+	return l
+}
+
+// LcmSlice computes the LCM of a slice of numbers. It silently wraps around like Lcm,
+// use LcmSliceChecked to detect overflows.
+func LcmSlice[T Integer](numbers []T) T {
+	if len(numbers) == 0 {
+		return 0 // No LCM for empty slice
+	}
+
+	result := numbers[0]
+	for _, number := range numbers[1:] {
+		result = Lcm(result, number)
+	}
+	return result
+}
+
+// LcmChecked computes the Least Common Multiple of two numbers like Lcm,
+// and returns ErrOverflow if it does not fit in an int64.
+func LcmChecked(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	if a == math.MinInt64 || b == math.MinInt64 {
+		// Its absolute value, a factor of the LCM, does not fit.
+		return 0, ErrOverflow
+	}
+
+	l, ok := MulChecked(a/Gcd(a, b), b)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	if l < 0 {
+		return -l, nil
+	}
+	return l, nil
+}
+
+// LcmSliceChecked computes the LCM of a slice of numbers like LcmSlice,
+// and returns ErrOverflow if it does not fit in an int64.
+func LcmSliceChecked(numbers []int64) (int64, error) {
+	if len(numbers) == 0 {
+		return 0, nil // No LCM for empty slice
+	}
+
+	result := int64(1)
+	for _, number := range numbers {
+		var errLcm error
+		if result, errLcm = LcmChecked(result, number); errLcm != nil {
+			return 0, errLcm
+		}
+	}
+	return result, nil
+}
+
+// ExtendedGcd computes the Greatest Common Divisor g of a and b along with Bézout coefficients x and y,
+// such that a*x + b*y = g. The returned g is never negative.
+func ExtendedGcd(a, b int64) (g, x, y int64) {
+	oldR, r := a, b
+	oldS, s := int64(1), int64(0)
+	oldT, t := int64(0), int64(1)
+
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldS, s = s, oldS-q*s
+		oldT, t = t, oldT-q*t
+	}
+
+	if oldR < 0 {
+		return -oldR, -oldS, -oldT
+	}
+	return oldR, oldS, oldT
+}
+
+// Mod returns a modulo m in [0, m), whatever the sign of a. m must be positive.
+func Mod(a, m int64) int64 {
+	r := a % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
+
+// mulMod computes a*b mod m without overflowing, for a and b in [0, m).
+func mulMod(a, b, m int64) int64 {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	return int64(bits.Rem64(hi, lo, uint64(m)))
+}
+
+// Crt solves the system of congruences x ≡ residues[i] (mod moduli[i]) with the Chinese Remainder Theorem.
+// Moduli do not need to be pairwise coprime. It returns the smallest non-negative solution x and the
+// modulus m of the solution, the LCM of the moduli: every solution is x plus a multiple of m.
+// It returns ErrNoSolution if the congruences contradict each other, and ErrOverflow if m does not fit in an int64.
+func Crt(residues, moduli []int64) (int64, int64, error) {
+	if len(residues) != len(moduli) {
+		return 0, 0, fmt.Errorf("got %d residues for %d moduli", len(residues), len(moduli))
+	}
+
+	x, m := int64(0), int64(1)
+	for i, modulus := range moduli {
+		if modulus <= 0 {
+			return 0, 0, fmt.Errorf("invalid modulus: %d", modulus)
+		}
+
+		// Find k such that x + m*k ≡ r (mod modulus), which requires gcd(m, modulus) to divide r - x.
+		r := Mod(residues[i], modulus)
+		g, inv, _ := ExtendedGcd(m, modulus)
+		diff := r - x
+		if diff%g != 0 {
+			return 0, 0, ErrNoSolution
+		}
+
+		step := modulus / g
+		k := mulMod(Mod(diff/g, step), Mod(inv, step), step)
+
+		newM, ok := MulChecked(m, step)
+		if !ok {
+			return 0, 0, ErrOverflow
+		}
+
+		x += m * k // Less than newM, so it cannot overflow either.
+		m = newM
+	}
+
+	return x, m, nil
+}
+
+// Isqrt returns the integer square root of n, the largest integer whose square is at most n.
+// It panics if n is negative.
+func Isqrt(n int64) int64 {
+	if n < 0 {
+		panic(fmt.Sprintf("numtheory: square root of negative number %d", n))
+	}
+	if n < 2 {
+		return n
+	}
+
+	// The float estimate can be off by one for large numbers, fix it with exact integer comparisons.
+	r := int64(math.Sqrt(float64(n)))
+	for r > n/r {
+		r--
+	}
+	for r+1 <= n/(r+1) {
+		r++
+	}
+	return r
+}
+
+// MulChecked returns a*b, and false if the product overflows an int64.
+// It has no math/big counterpart, as multiplying big.Int values cannot overflow.
+func MulChecked(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+
+	c := a * b
+	if (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) || c/b != a {
+		return c, false
+	}
+	return c, true
+}
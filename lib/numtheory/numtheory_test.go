@@ -0,0 +1,142 @@
+package numtheory
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestLcm(t *testing.T) {
+	tests := []struct {
+		a, b, want int64
+	}{
+		{4, 6, 12},
+		{-4, 6, 12},
+		{7, 13, 91},
+		{0, 5, 0},
+		{12, 12, 12},
+	}
+
+	for _, tt := range tests {
+		if got := Lcm(tt.a, tt.b); got != tt.want {
+			t.Errorf("Lcm(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got, err := LcmChecked(tt.a, tt.b); err != nil || got != tt.want {
+			t.Errorf("LcmChecked(%d, %d) = %d, %v, want %d", tt.a, tt.b, got, err, tt.want)
+		}
+		if got := LcmBig(big.NewInt(tt.a), big.NewInt(tt.b)); got.Int64() != tt.want {
+			t.Errorf("LcmBig(%d, %d) = %s, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestLcmChecked(t *testing.T) {
+	tests := []struct {
+		name    string
+		numbers []int64
+		want    int64
+		err     error
+	}{
+		{"empty", nil, 0, nil},
+		{"single negative", []int64{-9}, 9, nil},
+		{"fits", []int64{1 << 20, 3 << 20, 5 << 30}, 15 << 30, nil},
+		{"large primes", []int64{2147483647, 2147483629}, 2147483647 * 2147483629, nil},
+		{"overflows", []int64{2147483647, 2147483629, 7}, 0, ErrOverflow},
+		{"minimum int64", []int64{math.MinInt64}, 0, ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		got, err := LcmSliceChecked(tt.numbers)
+		if !errors.Is(err, tt.err) || got != tt.want {
+			t.Errorf("%s: LcmSliceChecked(%v) = %d, %v, want %d, %v", tt.name, tt.numbers, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestCrt(t *testing.T) {
+	tests := []struct {
+		name             string
+		residues, moduli []int64
+		x, m             int64
+		err              error
+	}{
+		{"coprime", []int64{2, 3, 2}, []int64{3, 5, 7}, 23, 105, nil},
+		{"negative residues", []int64{-1, -1}, []int64{4, 9}, 35, 36, nil},
+		{"non-coprime", []int64{3, 5}, []int64{4, 6}, 11, 12, nil},
+		{"non-coprime without solution", []int64{1, 2}, []int64{4, 6}, 0, 0, ErrNoSolution},
+		{"same modulus, different residues", []int64{1, 2}, []int64{5, 5}, 0, 0, ErrNoSolution},
+		{"overflowing modulus", []int64{0, 0, 0}, []int64{2147483647, 2147483629, 7}, 0, 0, ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		x, m, err := Crt(tt.residues, tt.moduli)
+		if !errors.Is(err, tt.err) || x != tt.x || m != tt.m {
+			t.Errorf("%s: Crt = %d, %d, %v, want %d, %d, %v", tt.name, x, m, err, tt.x, tt.m, tt.err)
+		}
+
+		if tt.err == ErrOverflow {
+			continue
+		}
+
+		residues, moduli := []*big.Int{}, []*big.Int{}
+		for i := range tt.residues {
+			residues = append(residues, big.NewInt(tt.residues[i]))
+			moduli = append(moduli, big.NewInt(tt.moduli[i]))
+		}
+		bx, bm, err := CrtBig(residues, moduli)
+		if !errors.Is(err, tt.err) || (err == nil && (bx.Int64() != tt.x || bm.Int64() != tt.m)) {
+			t.Errorf("%s: CrtBig = %v, %v, %v, want %d, %d, %v", tt.name, bx, bm, err, tt.x, tt.m, tt.err)
+		}
+	}
+}
+
+func TestIsqrt(t *testing.T) {
+	tests := []int64{0, 1, 2, 3, 4, 15, 16, 17, 99, 100, 101, 3037000499 * 3037000499, math.MaxInt64}
+
+	for _, n := range tests {
+		// Also check the numbers right around perfect squares of large roots, where the float estimate is off.
+		for _, m := range []int64{n - 1, n, n + 1} {
+			if m < 0 { // n+1 wraps around for the largest int64.
+				continue
+			}
+
+			r := Isqrt(m)
+			if want := IsqrtBig(big.NewInt(m)).Int64(); r != want {
+				t.Errorf("Isqrt(%d) = %d, IsqrtBig = %d", m, r, want)
+			}
+		}
+	}
+
+	if got := Isqrt(3037000499*3037000499 - 1); got != 3037000498 {
+		t.Errorf("Isqrt just below a large square = %d, want 3037000498", got)
+	}
+}
+
+func TestMulChecked(t *testing.T) {
+	tests := []struct {
+		a, b int64
+		ok   bool
+	}{
+		{0, math.MinInt64, true},
+		{1, math.MinInt64, true},
+		{-1, math.MaxInt64, true},
+		{-1, math.MinInt64, false},
+		{math.MinInt64, -1, false},
+		{1 << 31, 1 << 31, true},
+		{1 << 32, 1 << 31, false},
+		{3037000499, 3037000499, true},
+		{3037000500, 3037000500, false},
+		{-3037000500, 3037000500, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := MulChecked(tt.a, tt.b)
+		if ok != tt.ok {
+			t.Errorf("MulChecked(%d, %d) ok = %v, want %v", tt.a, tt.b, ok, tt.ok)
+		}
+		if ok && got != tt.a*tt.b {
+			t.Errorf("MulChecked(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.a*tt.b)
+		}
+	}
+}
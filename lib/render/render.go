@@ -0,0 +1,140 @@
+// Package render draws grids as PNG images and animated GIFs, for solvers to show their states.
+// Images are written to the directory named by the AOC_RENDER environment variable,
+// and solvers only render anything when that variable is set.
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+)
+
+// EnvVar is the environment variable holding the directory images are written to.
+const EnvVar = "AOC_RENDER"
+
+// Enabled returns true if images should be rendered. Solvers check it before doing any work only needed
+// by the images, so that rendering does not slow down regular runs.
+func Enabled() bool {
+	return os.Getenv(EnvVar) != ""
+}
+
+// Frame draws a grid as an image where each cell is a square of scale by scale pixels,
+// painted with the color of the palette at the index returned by colorIndex.
+func Frame[T any](g grid.Grid[T], scale int, palette color.Palette, colorIndex func(x, y int, cell T) uint8) *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, g.Width()*scale, g.Height()*scale), palette)
+
+	for y := 0; y < g.Height(); y++ {
+		for x, cell := range g.Row(y) {
+			FillRect(img, image.Rect(x*scale, y*scale, (x+1)*scale, (y+1)*scale), colorIndex(x, y, cell))
+		}
+	}
+
+	return img
+}
+
+// FillRect paints a rectangle of an image with the color of its palette at index colorIndex.
+// Parts of the rectangle outside of the image are ignored.
+func FillRect(img *image.Paletted, r image.Rectangle, colorIndex uint8) {
+	r = r.Intersect(img.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.SetColorIndex(x, y, colorIndex)
+		}
+	}
+}
+
+// Animation is a sequence of frames to be written as an animated GIF.
+type Animation struct {
+	frames []*image.Paletted
+	delays []int // In hundredths of a second, as GIF wants them.
+}
+
+// Add appends a frame shown for the given duration to the animation.
+func (a *Animation) Add(frame *image.Paletted, delay time.Duration) {
+	a.frames = append(a.frames, frame)
+	a.delays = append(a.delays, int(delay/(10*time.Millisecond)))
+}
+
+// Len returns the number of frames of the animation.
+func (a *Animation) Len() int {
+	return len(a.frames)
+}
+
+// path returns the path of the image file with the given name in the render directory, creating the directory if needed.
+func path(name string) (string, error) {
+	dir := os.Getenv(EnvVar)
+	if dir == "" {
+		return "", fmt.Errorf("cannot render %s: %s is not set", name, EnvVar)
+	}
+
+	if errMkdir := os.MkdirAll(dir, 0o755); errMkdir != nil {
+		return "", fmt.Errorf("cannot render %s: %w", name, errMkdir)
+	}
+
+	return filepath.Join(dir, name), nil
+}
+
+// WritePNG writes an image as <name>.png in the render directory.
+func WritePNG(name string, img image.Image) error {
+	p, errPath := path(name + ".png")
+	if errPath != nil {
+		return errPath
+	}
+
+	file, errCreate := os.Create(p)
+	if errCreate != nil {
+		return fmt.Errorf("cannot render %s: %w", name, errCreate)
+	}
+	defer file.Close()
+
+	if errEncode := png.Encode(file, img); errEncode != nil {
+		return fmt.Errorf("cannot render %s: %w", name, errEncode)
+	}
+
+	return file.Close()
+}
+
+// WriteGIF writes an animation as <name>.gif in the render directory.
+// Frames may have different sizes: the animation is as large as the largest of them.
+func WriteGIF(name string, a *Animation) error {
+	if a.Len() == 0 {
+		return fmt.Errorf("cannot render %s: the animation has no frame", name)
+	}
+
+	p, errPath := path(name + ".gif")
+	if errPath != nil {
+		return errPath
+	}
+
+	// Clear every frame before drawing the next one, which may be smaller.
+	anim := &gif.GIF{
+		Image:    a.frames,
+		Delay:    a.delays,
+		Disposal: make([]byte, a.Len()),
+	}
+	for i, frame := range a.frames {
+		anim.Disposal[i] = gif.DisposalBackground
+		anim.Config.Width = max(anim.Config.Width, frame.Bounds().Max.X)
+		anim.Config.Height = max(anim.Config.Height, frame.Bounds().Max.Y)
+	}
+	anim.Config.ColorModel = a.frames[0].Palette
+
+	file, errCreate := os.Create(p)
+	if errCreate != nil {
+		return fmt.Errorf("cannot render %s: %w", name, errCreate)
+	}
+	defer file.Close()
+
+	if errEncode := gif.EncodeAll(file, anim); errEncode != nil {
+		return fmt.Errorf("cannot render %s: %w", name, errEncode)
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,141 @@
+// Package repl provides an interactive read-eval-print loop to call the domain functions of a puzzle
+// on its parsed input.
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// EnvVar is the environment variable which makes solvers start a REPL instead of solving the puzzle.
+const EnvVar = "AOC_REPL"
+
+// Enabled returns true if the solver was asked to start a REPL.
+func Enabled() bool {
+	return os.Getenv(EnvVar) != ""
+}
+
+// Command is a domain function which can be called from the REPL.
+type Command struct {
+	Name string                              // Name used to call the command.
+	Args string                              // Description of the arguments, shown by help.
+	Help string                              // One line description of the command, shown by help.
+	Run  func(args []string) (string, error) // Function evaluating the command and returning what to print.
+}
+
+// Split splits a command line into fields separated by spaces.
+// Double quotes group several words into a single field.
+func Split(line string) ([]string, error) {
+	fields := []string{}
+	var current strings.Builder
+	inField, inQuotes := false, false
+
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			inField = true
+		case (r == ' ' || r == '\t') && !inQuotes:
+			if inField {
+				fields = append(fields, current.String())
+				current.Reset()
+				inField = false
+			}
+		default:
+			current.WriteRune(r)
+			inField = true
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+
+	if inField {
+		fields = append(fields, current.String())
+	}
+
+	return fields, nil
+}
+
+// Run reads commands from in, one per line, evaluates them and writes their results to out,
+// until in is exhausted or the quit command is read. Besides the given commands, help lists
+// the available commands and quit leaves the REPL.
+func Run(in io.Reader, out io.Writer, name string, cmds []Command) error {
+	byName := make(map[string]Command)
+	for _, cmd := range cmds {
+		byName[cmd.Name] = cmd
+	}
+
+	fmt.Fprintf(out, "%s, type help to list commands\n", name)
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+
+		fields, errSplit := Split(scanner.Text())
+		if errSplit != nil {
+			fmt.Fprintf(out, "error: %v\n", errSplit)
+			continue
+		}
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "quit", "exit":
+			return nil
+		case "help":
+			printHelp(out, cmds)
+			continue
+		}
+
+		cmd, exists := byName[fields[0]]
+		if !exists {
+			fmt.Fprintf(out, "error: unknown command %s, type help to list commands\n", fields[0])
+			continue
+		}
+
+		result, errRun := cmd.Run(fields[1:])
+		if errRun != nil {
+			fmt.Fprintf(out, "error: %v\n", errRun)
+			continue
+		}
+
+		if result != "" {
+			fmt.Fprintln(out, result)
+		}
+	}
+}
+
+// printHelp writes the usage of every command to out.
+func printHelp(out io.Writer, cmds []Command) {
+	sorted := append([]Command{}, cmds...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	for _, cmd := range sorted {
+		fmt.Fprintf(out, "  %s\n      %s\n", strings.TrimSpace(cmd.Name+" "+cmd.Args), cmd.Help)
+	}
+	fmt.Fprintln(out, "  help\n      list commands")
+	fmt.Fprintln(out, "  quit\n      leave the REPL")
+}
+
+// ExpectArgs returns an error unless args holds exactly n arguments.
+func ExpectArgs(args []string, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("wrong number of arguments: expected %d, got %d", n, len(args))
+	}
+
+	return nil
+}
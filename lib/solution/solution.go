@@ -0,0 +1,53 @@
+// Package solution prints the answers of the solvers, along with statistics about their run when asked to.
+package solution
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"time"
+)
+
+// StatsEnvVar is the environment variable holding the path of the file where Print writes run statistics.
+const StatsEnvVar = "AOC_STATS"
+
+// start approximates the moment the solver started, as packages are initialized before main runs.
+var start = time.Now()
+
+// Stats describes the run of a solver up to the moment it printed its answer.
+type Stats struct {
+	Duration time.Duration `json:"duration"` // Time elapsed since the solver started.
+	Allocs   uint64        `json:"allocs"`   // Number of heap allocations.
+	Bytes    uint64        `json:"bytes"`    // Total bytes allocated on the heap.
+}
+
+// Print prints the answer of the puzzle. If the StatsEnvVar environment variable is set,
+// statistics about the run are also written as JSON to the file it names.
+func Print(answer any) {
+	elapsed := time.Since(start)
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	fmt.Println(answer)
+
+	path := os.Getenv(StatsEnvVar)
+	if path == "" {
+		return
+	}
+
+	data, errJSON := json.Marshal(Stats{
+		Duration: elapsed,
+		Allocs:   mem.Mallocs,
+		Bytes:    mem.TotalAlloc,
+	})
+	if errJSON != nil {
+		log.Fatal(errJSON)
+	}
+
+	if errWrite := os.WriteFile(path, data, 0o644); errWrite != nil {
+		log.Fatal(errWrite)
+	}
+}
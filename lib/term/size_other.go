@@ -0,0 +1,11 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package term
+
+import "os"
+
+// size returns the number of columns and rows of the terminal, and false if they cannot be found.
+// The size cannot be found on this platform, so frames are never cropped.
+func size(file *os.File) (int, int, bool) {
+	return 0, 0, false
+}
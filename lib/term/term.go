@@ -0,0 +1,180 @@
+// Package term animates grids in a terminal with ANSI escape sequences, redrawing each frame in place.
+// Animation is enabled by setting the AOC_ANIMATE environment variable to a frame rate, and only happens
+// when the standard output is a terminal: otherwise solvers run as usual, without drawing anything.
+package term
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/maaxleq/advent-of-code-2023/lib/grid"
+)
+
+// EnvVar is the environment variable holding the number of frames to draw per second.
+const EnvVar = "AOC_ANIMATE"
+
+// ANSI escape sequences used to draw frames.
+const (
+	hideCursor = "\x1b[?25l"
+	showCursor = "\x1b[?25h"
+	clearLine  = "\x1b[K"
+	resetStyle = "\x1b[0m"
+)
+
+// Color is the color of a character in the terminal, from the 16 standard ANSI colors.
+type Color int
+
+// Colors are listed in the order of their ANSI codes, normal ones then bright ones.
+const (
+	Default Color = iota
+	Black
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	White
+	Gray
+	BrightRed
+	BrightGreen
+	BrightYellow
+	BrightBlue
+	BrightMagenta
+	BrightCyan
+	BrightWhite
+)
+
+// sgr returns the escape sequence switching the foreground to the color.
+func (c Color) sgr() string {
+	switch {
+	case c == Default:
+		return "\x1b[39m"
+	case c <= White:
+		return fmt.Sprintf("\x1b[%dm", 30+int(c-Black))
+	default:
+		return fmt.Sprintf("\x1b[%dm", 90+int(c-Gray))
+	}
+}
+
+// Cell is how a cell of a grid is drawn: a character and its color.
+type Cell struct {
+	Rune  rune
+	Color Color
+}
+
+// Enabled returns true if animation was requested. Solvers still need Start to succeed to draw anything.
+func Enabled() bool {
+	return os.Getenv(EnvVar) != ""
+}
+
+// Screen draws frames on the standard output, at most at the requested frame rate.
+// A nil *Screen is valid and draws nothing, so that solvers do not need to check whether animation is enabled.
+type Screen struct {
+	out           *bufio.Writer
+	delay         time.Duration // Minimum time between two frames.
+	next          time.Time     // Earliest time to draw the next frame.
+	width, height int           // Size of the terminal, 0 if unknown.
+	drawn         int           // Number of lines of the previous frame.
+}
+
+// Start returns a screen to draw frames on, or nil if animation is not enabled.
+// It also returns nil, explaining why on the standard error, if the frame rate is invalid or if the
+// standard output is not a terminal, in which case the escape sequences would only garble the output.
+func Start() *Screen {
+	if !Enabled() {
+		return nil
+	}
+
+	fps, errFps := strconv.Atoi(os.Getenv(EnvVar))
+	if errFps != nil || fps <= 0 {
+		fmt.Fprintf(os.Stderr, "animation disabled: invalid frame rate %q\n", os.Getenv(EnvVar))
+		return nil
+	}
+
+	if !isTerminal(os.Stdout) {
+		fmt.Fprintln(os.Stderr, "animation disabled: standard output is not a terminal")
+		return nil
+	}
+
+	s := &Screen{
+		out:   bufio.NewWriter(os.Stdout),
+		delay: time.Second / time.Duration(fps),
+	}
+	s.width, s.height, _ = size(os.Stdout)
+	s.out.WriteString(hideCursor)
+
+	return s
+}
+
+// isTerminal returns true if the file is a terminal rather than a pipe or a regular file.
+func isTerminal(file *os.File) bool {
+	info, errStat := file.Stat()
+	return errStat == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Draw draws a grid on the screen in place of the previous frame, each cell as returned by style,
+// with a caption below it. Grids larger than the terminal are cropped to their top left corner.
+// Draw waits as long as needed to respect the frame rate of the screen.
+func Draw[T any](s *Screen, g grid.Grid[T], style func(x, y int, cell T) Cell, caption string) {
+	if s == nil {
+		return
+	}
+
+	width, height := g.Width(), g.Height()
+	if s.width > 0 {
+		width = min(width, s.width)
+	}
+	if s.height > 1 {
+		height = min(height, s.height-1) // Keep a line for the caption.
+	}
+	if width < g.Width() || height < g.Height() {
+		caption += " (cropped)"
+	}
+
+	// A caption wrapping over two lines would shift the next frame down.
+	if runes := []rune(caption); s.width > 0 && len(runes) > s.width {
+		caption = string(runes[:s.width])
+	}
+
+	if wait := time.Until(s.next); wait > 0 {
+		time.Sleep(wait)
+	}
+
+	// Go back to the first line of the previous frame, and overwrite it.
+	if s.drawn > 0 {
+		fmt.Fprintf(s.out, "\x1b[%dA\r", s.drawn)
+	}
+
+	for y := 0; y < height; y++ {
+		color := Default
+		s.out.WriteString(color.sgr())
+		for x := 0; x < width; x++ {
+			cell := style(x, y, g.At(x, y))
+			if cell.Color != color {
+				color = cell.Color
+				s.out.WriteString(color.sgr())
+			}
+			s.out.WriteRune(cell.Rune)
+		}
+		s.out.WriteString(resetStyle + clearLine + "\n")
+	}
+	s.out.WriteString(caption + clearLine + "\n")
+
+	s.drawn = height + 1
+	s.out.Flush()
+	s.next = time.Now().Add(s.delay)
+}
+
+// Stop ends the animation, leaving the last frame on the terminal.
+func (s *Screen) Stop() {
+	if s == nil {
+		return
+	}
+
+	s.out.WriteString(resetStyle + showCursor)
+	s.out.Flush()
+}
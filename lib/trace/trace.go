@@ -0,0 +1,95 @@
+// Package trace lets solvers emit structured events describing the steps of their algorithm.
+// Events are written as JSON lines to the file named by the AOC_TRACE environment variable,
+// and tracing costs nothing more than a check of Enabled when that variable is not set.
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+)
+
+// EnvVar is the environment variable holding the path of the file events are written to.
+// The special path "-" stands for the standard error.
+const EnvVar = "AOC_TRACE"
+
+var (
+	path = os.Getenv(EnvVar)
+
+	mu  sync.Mutex
+	out io.Writer
+)
+
+// Enabled returns true if events are being recorded. Solvers check it before computing
+// anything only needed by an event, so that tracing does not slow down regular runs.
+func Enabled() bool {
+	return path != ""
+}
+
+// writer returns the destination of the events, opening the trace file on first use.
+// It must be called with mu held.
+func writer() io.Writer {
+	if out != nil {
+		return out
+	}
+
+	if path == "-" {
+		out = os.Stderr
+		return out
+	}
+
+	file, errCreate := os.Create(path)
+	if errCreate != nil {
+		log.Fatalf("cannot create trace file: %v", errCreate)
+	}
+	out = file
+
+	return out
+}
+
+// Emit records an event with the given name and fields, passed as alternating keys and values:
+//
+//	trace.Emit("hop", "map", mapName, "from", num, "to", newNum)
+//
+// Fields are written in the given order. Emit does nothing if tracing is not enabled.
+func Emit(name string, keyvals ...any) {
+	if !Enabled() {
+		return
+	}
+
+	if len(keyvals)%2 != 0 {
+		panic(fmt.Sprintf("trace: odd number of key-value arguments for event %s", name))
+	}
+
+	var line bytes.Buffer
+	line.WriteString(`{"event":`)
+	writeJSON(&line, name)
+	for i := 0; i < len(keyvals); i += 2 {
+		line.WriteByte(',')
+		writeJSON(&line, fmt.Sprint(keyvals[i]))
+		line.WriteByte(':')
+		writeJSON(&line, keyvals[i+1])
+	}
+	line.WriteString("}\n")
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, errWrite := writer().Write(line.Bytes()); errWrite != nil {
+		log.Fatalf("cannot write trace event: %v", errWrite)
+	}
+}
+
+// writeJSON appends the JSON encoding of v to buf. Values which cannot be encoded are written as strings.
+func writeJSON(buf *bytes.Buffer, v any) {
+	data, errJSON := json.Marshal(v)
+	if errJSON != nil {
+		data, _ = json.Marshal(fmt.Sprint(v))
+	}
+
+	buf.Write(data)
+}
@@ -1,3 +0,0 @@
-module github.com/maaxleq/advent-of-code-2023/puzzle-5/part-1
-
-go 1.21.1
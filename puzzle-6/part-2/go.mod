@@ -1,3 +0,0 @@
-module github.com/maaxleq/advent-of-code-2023/puzzle-6/part-2
-
-go 1.21.1